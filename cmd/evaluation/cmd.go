@@ -0,0 +1,35 @@
+package evaluation
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/chemtrail/cmd/evaluation/status"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "evaluation",
+		Short: "Inspect the decisions made by autoscaler evaluations",
+		Run: func(cmd *cobra.Command, args []string) {
+			runEvaluation(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	if err := registerCommands(cmd); err != nil {
+		fmt.Println("Error registering commands:", err)
+		os.Exit(sysexits.Software)
+	}
+	return nil
+}
+
+func runEvaluation(cmd *cobra.Command, _ []string) {
+	_ = cmd.Usage()
+}
+
+func registerCommands(cmd *cobra.Command) error {
+	return status.RegisterCommand(cmd)
+}
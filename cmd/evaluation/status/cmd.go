@@ -0,0 +1,152 @@
+package status
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jrasell/chemtrail/cmd/helper"
+	"github.com/jrasell/chemtrail/pkg/api"
+	clientCfg "github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listOutputHeader    = "Class|Time|Outcome|Direction|Reason|Detail"
+	historyOutputHeader = "ID|Time|Outcome|Direction|Count|Reason"
+	checksOutputHeader  = "Name|Source|Resource|Value|Threshold|Action|Breached|Fired"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Display the latest autoscaler evaluation of each class, or the evaluations of a class",
+		Run: func(cmd *cobra.Command, args []string) {
+			runStatus(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runStatus(_ *cobra.Command, args []string) {
+	if len(args) > 1 {
+		fmt.Println("Too many arguments, expected maximum 1 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	switch {
+	case len(args) == 1:
+		if err := runStatusClass(client, args[0]); err != nil {
+			fmt.Println("Error querying class evaluations:", err)
+			os.Exit(sysexits.Software)
+		}
+	case len(args) == 0:
+		if err := runStatusList(client); err != nil {
+			fmt.Println("Error querying evaluations:", err)
+			os.Exit(sysexits.Software)
+		}
+	}
+}
+
+func runStatusList(c *api.Client) error {
+	resp, err := c.Evaluation().List()
+	if err != nil {
+		return err
+	}
+
+	classes := make([]string, 0, len(resp))
+	for class := range resp {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	out := []string{listOutputHeader}
+
+	for _, class := range classes {
+		if len(resp[class]) == 0 {
+			continue
+		}
+		latest := resp[class][0]
+		out = append(out, fmt.Sprintf("%s|%v|%s|%s|%s|%s",
+			class, helper.UnixNanoToHumanUTC(latest.Timestamp), latest.Outcome, latest.Direction,
+			latest.Reason, latest.Detail))
+	}
+
+	if len(out) > 1 {
+		fmt.Println(helper.FormatList(out))
+	}
+	return nil
+}
+
+func runStatusClass(c *api.Client, class string) error {
+	resp, err := c.Evaluation().Class(class)
+	if err != nil {
+		return err
+	}
+
+	if len(resp) == 0 {
+		return nil
+	}
+	latest := resp[0]
+
+	header := []string{
+		fmt.Sprintf("ID|%s", latest.ID),
+		fmt.Sprintf("Class|%s", latest.Class),
+		fmt.Sprintf("Time|%v", helper.UnixNanoToHumanUTC(latest.Timestamp)),
+		fmt.Sprintf("Strategy|%s", latest.Strategy),
+		fmt.Sprintf("Schedule|%s", latest.Schedule),
+		fmt.Sprintf("Outcome|%s", latest.Outcome),
+		fmt.Sprintf("Reason|%s", latest.Reason),
+		fmt.Sprintf("Detail|%s", latest.Detail),
+		fmt.Sprintf("Direction|%s", latest.Direction),
+		fmt.Sprintf("ActivityID|%s", latest.ActivityID),
+	}
+
+	fmt.Println(helper.FormatKV(header))
+
+	if len(latest.Checks) > 0 {
+		fmt.Println("")
+		fmt.Println(helper.FormatList(formatChecks(latest.Checks)))
+	}
+
+	history := []string{historyOutputHeader}
+	for _, rec := range resp {
+		history = append(history, fmt.Sprintf("%s|%v|%s|%s|%v|%s",
+			rec.ID, helper.UnixNanoToHumanUTC(rec.Timestamp), rec.Outcome, rec.Direction, rec.Count, rec.Reason))
+	}
+
+	fmt.Println("")
+	fmt.Println(helper.FormatList(history))
+	return nil
+}
+
+// formatChecks returns the check records as list output, sorted by the check name.
+func formatChecks(checks map[string]*state.CheckRecord) []string {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := []string{checksOutputHeader}
+
+	for _, name := range names {
+		c := checks[name]
+		out = append(out, fmt.Sprintf("%s|%s|%s|%v|%v|%s|%v|%v",
+			name, c.Source, c.Resource, c.Value, c.Threshold, c.Action, c.Breached, c.Fired))
+	}
+	return out
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jrasell/chemtrail/cmd/evaluation"
+	"github.com/jrasell/chemtrail/cmd/node"
 	"github.com/jrasell/chemtrail/cmd/policy"
 
 	"github.com/jrasell/chemtrail/cmd/scale"
@@ -52,6 +54,14 @@ func registerCommands(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	if err := evaluation.RegisterCommand(rootCmd); err != nil {
+		return err
+	}
+
+	if err := node.RegisterCommand(rootCmd); err != nil {
+		return err
+	}
+
 	if err := system.RegisterCommand(rootCmd); err != nil {
 		return err
 	}
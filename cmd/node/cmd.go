@@ -0,0 +1,35 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/chemtrail/cmd/node/list"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Inspect the Nomad client nodes tracked by Chemtrail",
+		Run: func(cmd *cobra.Command, args []string) {
+			runNode(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	if err := registerCommands(cmd); err != nil {
+		fmt.Println("Error registering commands:", err)
+		os.Exit(sysexits.Software)
+	}
+	return nil
+}
+
+func runNode(cmd *cobra.Command, _ []string) {
+	_ = cmd.Usage()
+}
+
+func registerCommands(cmd *cobra.Command) error {
+	return list.RegisterCommand(cmd)
+}
@@ -0,0 +1,68 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/chemtrail/cmd/helper"
+	"github.com/jrasell/chemtrail/pkg/api"
+	clientCfg "github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const outputHeader = "ID|Class|Status|Eligibility|Allocations|Protected"
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the nodes of all classes, or the nodes of a class",
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runList(_ *cobra.Command, args []string) {
+	if len(args) > 1 {
+		fmt.Println("Too many arguments, expected maximum 1 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	var nodes []*state.NodeStatus
+
+	if len(args) == 1 {
+		nodes, err = client.Node().Class(args[0])
+	} else {
+		nodes, err = client.Node().List()
+	}
+	if err != nil {
+		fmt.Println("Error querying nodes:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	out := []string{outputHeader}
+
+	for _, node := range nodes {
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%v|%v",
+			node.ID, node.Class, node.Status, node.Eligibility, node.Allocations, node.Protected))
+	}
+	fmt.Println(helper.FormatList(out))
+}
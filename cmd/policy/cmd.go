@@ -7,7 +7,9 @@ import (
 	"github.com/jrasell/chemtrail/cmd/policy/delete"
 	initcmd "github.com/jrasell/chemtrail/cmd/policy/init"
 	"github.com/jrasell/chemtrail/cmd/policy/list"
+	"github.com/jrasell/chemtrail/cmd/policy/plan"
 	"github.com/jrasell/chemtrail/cmd/policy/read"
+	"github.com/jrasell/chemtrail/cmd/policy/schedules"
 	"github.com/jrasell/chemtrail/cmd/policy/write"
 	"github.com/sean-/sysexits"
 	"github.com/spf13/cobra"
@@ -43,10 +45,18 @@ func registerCommands(cmd *cobra.Command) error {
 		return err
 	}
 
+	if err := plan.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
 	if err := read.RegisterCommand(cmd); err != nil {
 		return err
 	}
 
+	if err := schedules.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
 	if err := initcmd.RegisterCommand(cmd); err != nil {
 		return err
 	}
@@ -0,0 +1,113 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jrasell/chemtrail/cmd/helper"
+	"github.com/jrasell/chemtrail/pkg/api"
+	"github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const (
+	checksOutputHeader = "Name|Source|Resource|Value|Threshold|Action|Breached|Fired|Desired"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Perform a dry-run evaluation of a scaling policy",
+		Run: func(cmd *cobra.Command, args []string) {
+			runPlan(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runPlan(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) < 1:
+		fmt.Println("Not enough arguments, expected minimum 1 args got", len(args))
+		os.Exit(sysexits.Usage)
+	case len(args) > 2:
+		fmt.Println("Too many arguments, expected maximum 2 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	// If a policy file is passed, this is evaluated in place of the stored class policy.
+	var policy *api.ScalingPolicy
+
+	if len(args) == 2 {
+		b, err := ioutil.ReadFile(strings.TrimSpace(args[1]))
+		if err != nil {
+			fmt.Println("Error reading scaling policy file:", err)
+			os.Exit(sysexits.Software)
+		}
+
+		if err = json.Unmarshal(b, &policy); err != nil {
+			fmt.Println("Error parsing scaling policy file:", err)
+			os.Exit(sysexits.Software)
+		}
+	}
+
+	clientConfig := client.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	chemtrailClient, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	rec, err := chemtrailClient.Policy().Plan(args[0], policy)
+	if err != nil {
+		fmt.Println("Error planning class scaling policy:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	header := []string{
+		fmt.Sprintf("Class|%s", rec.Class),
+		fmt.Sprintf("Strategy|%s", rec.Strategy),
+		fmt.Sprintf("Schedule|%s", rec.Schedule),
+		fmt.Sprintf("Outcome|%s", rec.Outcome),
+		fmt.Sprintf("Reason|%s", rec.Reason),
+		fmt.Sprintf("Detail|%s", rec.Detail),
+		fmt.Sprintf("Direction|%s", rec.Direction),
+		fmt.Sprintf("Count|%v", rec.Count),
+	}
+
+	fmt.Println(helper.FormatKV(header))
+
+	if len(rec.Checks) > 0 {
+		fmt.Println("")
+		fmt.Println(helper.FormatList(formatChecks(rec.Checks)))
+	}
+	os.Exit(sysexits.OK)
+}
+
+// formatChecks returns the check records as list output, sorted by the check name.
+func formatChecks(checks map[string]*state.CheckRecord) []string {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := []string{checksOutputHeader}
+
+	for _, name := range names {
+		c := checks[name]
+		out = append(out, fmt.Sprintf("%s|%s|%s|%v|%v|%s|%v|%v|%v",
+			name, c.Source, c.Resource, c.Value, c.Threshold, c.Action, c.Breached, c.Fired, c.DesiredCount))
+	}
+	return out
+}
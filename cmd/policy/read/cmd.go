@@ -13,7 +13,9 @@ import (
 )
 
 const (
-	checkHeader = "Name|Enabled|Resource|Operator|Value|Action"
+	checkHeader      = "Name|Enabled|Resource|Operator|Value|Action|Breaches"
+	targetHeader     = "Name|Enabled|Resource|Target|Tolerance|MaxChange"
+	expressionHeader = "Action|Expression"
 )
 
 func RegisterCommand(rootCmd *cobra.Command) error {
@@ -61,6 +63,13 @@ func runRead(_ *cobra.Command, args []string) {
 		fmt.Sprintf("MinCount|%v", policy.MinCount),
 		fmt.Sprintf("ScaleInCount|%v", policy.ScaleInCount),
 		fmt.Sprintf("ScaleOutCount|%v", policy.ScaleOutCount),
+		fmt.Sprintf("ScaleInCooldown|%v", policy.ScaleInCooldown),
+		fmt.Sprintf("ScaleOutCooldown|%v", policy.ScaleOutCooldown),
+		fmt.Sprintf("ScaleOutMode|%v", policy.ScaleOutMode),
+		fmt.Sprintf("ScaleOutJoinTimeout|%v", policy.ScaleOutJoinTimeout),
+		fmt.Sprintf("EvaluationInterval|%v", policy.EvaluationInterval),
+		fmt.Sprintf("Strategy|%v", policy.Strategy),
+		fmt.Sprintf("ScaleInSelector|%s", formatSelector(policy.ScaleInSelector)),
 		fmt.Sprintf("Provider|%v", policy.Provider),
 		fmt.Sprintf("ProviderConfig|%s", strings.Join(helper.MapStringsToSliceString(policy.ProviderConfig, ":"), ",")),
 	}
@@ -71,8 +80,30 @@ func runRead(_ *cobra.Command, args []string) {
 		checks = append(checks, checkHeader)
 
 		for name, check := range policy.Checks {
-			checks = append(checks, fmt.Sprintf("%s|%v|%s|%s|%v|%s",
-				name, check.Enabled, check.Resource, check.ComparisonOperator, check.ComparisonPercentage, check.Action))
+			checks = append(checks, fmt.Sprintf("%s|%v|%s|%s|%v|%s|%s",
+				name, check.Enabled, check.Resource, check.ComparisonOperator, check.ComparisonPercentage,
+				check.Action, formatBreaches(check)))
+		}
+	}
+
+	var targets []string
+
+	if len(policy.Targets) > 0 {
+		targets = append(targets, targetHeader)
+
+		for name, target := range policy.Targets {
+			targets = append(targets, fmt.Sprintf("%s|%v|%s|%v|%v|%v",
+				name, target.Enabled, target.Resource, target.TargetValue, target.Tolerance, target.MaxChange))
+		}
+	}
+
+	var expressions []string
+
+	if len(policy.Expressions) > 0 {
+		expressions = append(expressions, expressionHeader)
+
+		for action, expression := range policy.Expressions {
+			expressions = append(expressions, fmt.Sprintf("%s|%s", action, expression))
 		}
 	}
 
@@ -81,4 +112,36 @@ func runRead(_ *cobra.Command, args []string) {
 	if len(checks) > 0 {
 		fmt.Println(helper.FormatList(checks))
 	}
+	if len(targets) > 0 {
+		fmt.Println("")
+		fmt.Println(helper.FormatList(targets))
+	}
+	if len(expressions) > 0 {
+		fmt.Println("")
+		fmt.Println(helper.FormatList(expressions))
+	}
+}
+
+// formatSelector returns the scale in node selection strategy of the policy, applying the server
+// side default where the selector is not set.
+func formatSelector(selector *api.ScaleInSelector) string {
+	if selector == nil || selector.Strategy == "" {
+		return "least-allocated"
+	}
+	return selector.Strategy
+}
+
+// formatBreaches returns the N of M breach configuration of the check in a human readable form,
+// applying the server side defaults where the parameters are not set.
+func formatBreaches(check api.Check) string {
+	periods := check.EvaluationPeriods
+	if periods < 1 {
+		periods = 1
+	}
+
+	breaches := check.BreachesRequired
+	if breaches < 1 {
+		breaches = periods
+	}
+	return fmt.Sprintf("%v of %v", breaches, periods)
 }
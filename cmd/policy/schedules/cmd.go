@@ -0,0 +1,76 @@
+package schedules
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/chemtrail/cmd/helper"
+	"github.com/jrasell/chemtrail/pkg/api"
+	"github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputHeader = "Name|Active|Start|End|MinCount|MaxCount|DesiredCount"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "schedules",
+		Short: "Lists the active and upcoming schedule windows of a scaling policy",
+		Run: func(cmd *cobra.Command, args []string) {
+			runSchedules(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runSchedules(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) < 1:
+		fmt.Println("Not enough arguments, expected 1 args got", len(args))
+		os.Exit(sysexits.Usage)
+	case len(args) > 1:
+		fmt.Println("Too many arguments, expected 1 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := client.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	chemtrailClient, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	windows, err := chemtrailClient.Policy().Schedules(args[0])
+	if err != nil {
+		fmt.Println("Error listing scaling policy schedules:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	if len(windows) == 0 {
+		os.Exit(sysexits.OK)
+	}
+	out := []string{outputHeader}
+
+	for _, w := range windows {
+		out = append(out, fmt.Sprintf("%s|%v|%v|%v|%s|%s|%s",
+			w.Name, w.Active, helper.UnixNanoToHumanUTC(w.Start), helper.UnixNanoToHumanUTC(w.End),
+			formatOverride(w.MinCount), formatOverride(w.MaxCount), formatOverride(w.DesiredCount)))
+	}
+	fmt.Println(helper.FormatList(out))
+}
+
+// formatOverride returns the schedule override value, using an empty string where the value is
+// not set so the list output displays it as not configured.
+func formatOverride(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
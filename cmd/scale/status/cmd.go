@@ -3,6 +3,7 @@ package status
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,8 +16,9 @@ import (
 )
 
 const (
-	listOutputHeader   = "ID|Status|Provider|LastUpdate"
+	listOutputHeader   = "ID|Class|Status|Provider|LastUpdate"
 	eventsOutputHeader = "Time|Source|Event"
+	nodesOutputHeader  = "Node|Status|LastUpdate|Message"
 )
 
 func RegisterCommand(rootCmd *cobra.Command) error {
@@ -70,8 +72,8 @@ func runStatusList(c *api.Client) error {
 	out := []string{listOutputHeader}
 
 	for k, v := range *resp {
-		out = append(out, fmt.Sprintf("%s|%s|%s|%v",
-			k, v.Status, v.Provider.String(), time.Unix(0, v.LastUpdate).UTC()))
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%v",
+			k, v.Class, v.Status, v.Provider.String(), time.Unix(0, v.LastUpdate).UTC()))
 	}
 
 	if len(out) > 1 {
@@ -88,6 +90,7 @@ func runStatusInfo(c *api.Client, id string) error {
 
 	header := []string{
 		fmt.Sprintf("ID|%s", id),
+		fmt.Sprintf("Class|%s", resp.Class),
 		fmt.Sprintf("Status|%s", resp.Status),
 		fmt.Sprintf("LastUpdate|%v", time.Unix(0, resp.LastUpdate).UTC()),
 		fmt.Sprintf("Direction|%s", resp.Direction),
@@ -104,6 +107,26 @@ func runStatusInfo(c *api.Client, id string) error {
 	fmt.Println("")
 	fmt.Println(formatList(events))
 
+	// Scale in activities record the outcome of each targeted node, which is displayed in order of
+	// the node ID.
+	if len(resp.Nodes) > 0 {
+		ids := make([]string, 0, len(resp.Nodes))
+		for id := range resp.Nodes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		nodes := []string{nodesOutputHeader}
+		for _, id := range ids {
+			node := resp.Nodes[id]
+			nodes = append(nodes, fmt.Sprintf("%s|%s|%v|%s",
+				id, node.Status, time.Unix(0, node.LastUpdate).UTC(), node.Message))
+		}
+
+		fmt.Println("")
+		fmt.Println(formatList(nodes))
+	}
+
 	return nil
 }
 
@@ -26,6 +26,7 @@ func RegisterCommand(rootCmd *cobra.Command) error {
 	serverCfg.RegisterTelemetryConfig(cmd)
 	serverCfg.RegisterProviderConfig(cmd)
 	serverCfg.RegisterAutoscalerConfig(cmd)
+	serverCfg.RegisterMetricSourceConfig(cmd)
 	serverCfg.RegisterStorageConfig(cmd)
 	logCfg.RegisterConfig(cmd)
 	rootCmd.AddCommand(cmd)
@@ -35,6 +36,7 @@ func RegisterCommand(rootCmd *cobra.Command) error {
 
 func runServer(_ *cobra.Command, _ []string) {
 	autoscaleConfig := serverCfg.GetAutoscalerConfig()
+	metricSourceConfig := serverCfg.GetMetricSourceConfig()
 	providerConfig := serverCfg.GetProviderConfig()
 	storageConfig := serverCfg.GetStorageConfig()
 	serverConfig := serverCfg.GetConfig()
@@ -48,13 +50,19 @@ func runServer(_ *cobra.Command, _ []string) {
 		os.Exit(sysexits.Software)
 	}
 
+	if err := autoscaleConfig.Validate(); err != nil {
+		fmt.Println("Error validating autoscaler config:", err)
+		os.Exit(sysexits.Usage)
+	}
+
 	cfg := &server.Config{
-		Autoscale: autoscaleConfig,
-		Provider:  providerConfig,
-		Server:    &serverConfig,
-		Storage:   storageConfig,
-		TLS:       &tlsConfig,
-		Telemetry: &telemetryConfig,
+		Autoscale:    autoscaleConfig,
+		MetricSource: metricSourceConfig,
+		Provider:     providerConfig,
+		Server:       &serverConfig,
+		Storage:      storageConfig,
+		TLS:          &tlsConfig,
+		Telemetry:    &telemetryConfig,
 	}
 	srv := server.New(log.Logger, cfg)
 
@@ -0,0 +1,36 @@
+package autoscaler
+
+import (
+	"github.com/jrasell/chemtrail/cmd/system/autoscaler/pause"
+	"github.com/jrasell/chemtrail/cmd/system/autoscaler/resume"
+	"github.com/jrasell/chemtrail/cmd/system/autoscaler/status"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "autoscaler",
+		Short: "Interact with the autoscaler of a Chemtrail server",
+		Run: func(cmd *cobra.Command, args []string) {
+			runAutoscaler(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return registerCommands(cmd)
+}
+
+func runAutoscaler(cmd *cobra.Command, _ []string) {
+	_ = cmd.Usage()
+}
+
+func registerCommands(cmd *cobra.Command) error {
+	if err := pause.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
+	if err := resume.RegisterCommand(cmd); err != nil {
+		return err
+	}
+	return status.RegisterCommand(cmd)
+}
@@ -0,0 +1,63 @@
+package pause
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jrasell/chemtrail/cmd/system/autoscaler/status"
+	"github.com/jrasell/chemtrail/pkg/api"
+	"github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagDuration = "duration"
+	flagReason   = "reason"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause the autoscaler",
+		Run: func(cmd *cobra.Command, args []string) {
+			runPause(cmd, args)
+		},
+	}
+	cmd.Flags().Duration(flagDuration, 0, "The time period for which the autoscaler is paused, such as 30m; if not set the autoscaler is paused until resumed")
+	cmd.Flags().String(flagReason, "", "The reason for pausing the autoscaler")
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runPause(cmd *cobra.Command, _ []string) {
+	duration, _ := cmd.Flags().GetDuration(flagDuration)
+	reason, _ := cmd.Flags().GetString(flagReason)
+
+	if duration < 0 {
+		fmt.Println("Duration must not be negative")
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := client.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	chemtrailClient, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	// Round the duration up to the nearest second, as the API uses second granularity.
+	seconds := int((duration + time.Second - 1) / time.Second)
+
+	resp, err := chemtrailClient.System().PauseAutoscaler(seconds, reason)
+	if err != nil {
+		fmt.Println("Error pausing autoscaler:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(status.FormatStatus(resp))
+}
@@ -0,0 +1,44 @@
+package resume
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/chemtrail/cmd/system/autoscaler/status"
+	"github.com/jrasell/chemtrail/pkg/api"
+	"github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume a paused autoscaler",
+		Run: func(cmd *cobra.Command, args []string) {
+			runResume(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runResume(_ *cobra.Command, _ []string) {
+	clientConfig := client.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	chemtrailClient, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	resp, err := chemtrailClient.System().ResumeAutoscaler()
+	if err != nil {
+		fmt.Println("Error resuming autoscaler:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(status.FormatStatus(resp))
+}
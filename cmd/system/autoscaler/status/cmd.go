@@ -0,0 +1,64 @@
+package status
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/chemtrail/cmd/helper"
+	"github.com/jrasell/chemtrail/pkg/api"
+	"github.com/jrasell/chemtrail/pkg/config/client"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Display the status of the autoscaler",
+		Run: func(cmd *cobra.Command, args []string) {
+			runStatus(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runStatus(_ *cobra.Command, _ []string) {
+	clientConfig := client.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	chemtrailClient, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Chemtrail client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	status, err := chemtrailClient.System().AutoscalerStatus()
+	if err != nil {
+		fmt.Println("Error querying autoscaler status:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(FormatStatus(status))
+}
+
+// FormatStatus returns the autoscaler status formatted for CLI output.
+func FormatStatus(status *state.AutoscalerStatus) string {
+	out := []string{
+		fmt.Sprintf("Enabled|%v", status.Enabled),
+		fmt.Sprintf("Paused|%v", status.Paused),
+	}
+
+	if status.Pause != nil {
+		out = append(out,
+			fmt.Sprintf("PausedAt|%v", helper.UnixNanoToHumanUTC(status.Pause.Timestamp)),
+			fmt.Sprintf("Reason|%s", status.Pause.Reason))
+
+		if status.Pause.Expiry > 0 {
+			out = append(out, fmt.Sprintf("Expiry|%v", helper.UnixNanoToHumanUTC(status.Pause.Expiry)))
+		}
+	}
+	return helper.FormatKV(out)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jrasell/chemtrail/cmd/system/autoscaler"
 	"github.com/jrasell/chemtrail/cmd/system/health"
 	"github.com/jrasell/chemtrail/cmd/system/metrics"
 	"github.com/sean-/sysexits"
@@ -32,6 +33,10 @@ func runSystem(cmd *cobra.Command, _ []string) {
 }
 
 func registerCommands(cmd *cobra.Command) error {
+	if err := autoscaler.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
 	if err := metrics.RegisterCommand(cmd); err != nil {
 		return nil
 	}
@@ -6,6 +6,7 @@ require (
 	github.com/armon/go-metrics v0.3.0
 	github.com/aws/aws-sdk-go-v2 v0.17.0
 	github.com/gofrs/uuid v3.2.0+incompatible
+	github.com/gorhill/cronexpr v0.0.0-20180427100037-88b0669f7d75
 	github.com/gorilla/mux v1.7.3
 	github.com/hashicorp/consul/api v1.3.0
 	github.com/hashicorp/go-cleanhttp v0.5.1
@@ -134,7 +134,7 @@ func (c *Client) post(endpoint string, in, out interface{}) error {
 	}
 	r.obj = in
 	resp, err := c.doRequest(r)
-	resp, err = requireOK(resp, err, http.StatusCreated)
+	resp, err = requireOK(resp, err, http.StatusOK)
 	if err != nil {
 		return err
 	}
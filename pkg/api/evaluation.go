@@ -0,0 +1,29 @@
+package api
+
+import "github.com/jrasell/chemtrail/pkg/state"
+
+type Evaluation struct {
+	client *Client
+}
+
+func (c *Client) Evaluation() *Evaluation {
+	return &Evaluation{client: c}
+}
+
+func (e *Evaluation) List() (map[string][]*state.EvaluationRecord, error) {
+	var resp map[string][]*state.EvaluationRecord
+	err := e.client.get("/v1/evaluations", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (e *Evaluation) Class(class string) ([]*state.EvaluationRecord, error) {
+	var resp []*state.EvaluationRecord
+	err := e.client.get("/v1/evaluations/"+class, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
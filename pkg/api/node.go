@@ -0,0 +1,29 @@
+package api
+
+import "github.com/jrasell/chemtrail/pkg/state"
+
+type Node struct {
+	client *Client
+}
+
+func (c *Client) Node() *Node {
+	return &Node{client: c}
+}
+
+func (n *Node) List() ([]*state.NodeStatus, error) {
+	var resp []*state.NodeStatus
+	err := n.client.get("/v1/nodes", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (n *Node) Class(class string) ([]*state.NodeStatus, error) {
+	var resp []*state.NodeStatus
+	err := n.client.get("/v1/nodes/"+class, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
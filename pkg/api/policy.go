@@ -1,5 +1,7 @@
 package api
 
+import "github.com/jrasell/chemtrail/pkg/state"
+
 type Policy struct {
 	client *Client
 }
@@ -9,15 +11,27 @@ func (c *Client) Policy() *Policy {
 }
 
 type ScalingPolicy struct {
-	Enabled        bool
-	Class          string
-	MinCount       int
-	MaxCount       int
-	ScaleOutCount  int
-	ScaleInCount   int
-	Provider       string
-	ProviderConfig map[string]string
-	Checks         map[string]Check
+	Enabled             bool
+	Class               string
+	MinCount            int
+	MaxCount            int
+	ScaleOutCount       int
+	ScaleInCount        int
+	ScaleOutCooldown    int
+	ScaleInCooldown     int
+	ScaleOutMode        string
+	Provider            string
+	ProviderConfig      map[string]string
+	Checks              map[string]Check
+	Schedules           map[string]Schedule
+	Strategy            string
+	Targets             map[string]Target
+	Expressions         map[string]string
+	EvaluationInterval  int
+	ScaleInSelector     *ScaleInSelector
+	ScaleInProtection   *ScaleInProtection
+	Drain               *Drain
+	ScaleOutJoinTimeout int
 }
 
 type Check struct {
@@ -26,6 +40,65 @@ type Check struct {
 	ComparisonOperator   string
 	ComparisonPercentage float64
 	Action               string
+	EvaluationPeriods    int
+	BreachesRequired     int
+	AggregationWindow    int
+	AggregationFunction  string
+	StepAdjustments      []StepAdjustment
+	Source               string
+	Query                string
+}
+
+type StepAdjustment struct {
+	LowerBound     float64
+	UpperBound     float64
+	Adjustment     int
+	AdjustmentType string
+}
+
+type Target struct {
+	Enabled     bool
+	Resource    string
+	TargetValue float64
+	Tolerance   float64
+	MaxChange   int
+}
+
+type ScaleInSelector struct {
+	Strategy  string
+	Resource  string
+	Weights   map[string]float64
+	Attribute string
+}
+
+type ScaleInProtection struct {
+	JobIDs   []string
+	JobTypes []string
+	JobMeta  map[string]string
+	Window   *ProtectionWindow
+}
+
+type ProtectionWindow struct {
+	Cron     string
+	TimeZone string
+	Duration int
+}
+
+type Drain struct {
+	Deadline         int
+	IgnoreSystemJobs bool
+	Force            bool
+	Timeout          int
+	BatchSize        int
+}
+
+type Schedule struct {
+	Cron         string
+	TimeZone     string
+	Duration     int
+	MinCount     int
+	MaxCount     int
+	DesiredCount int
 }
 
 func (p *Policy) Delete(class string) error {
@@ -53,3 +126,27 @@ func (p *Policy) Info(class string) (*ScalingPolicy, error) {
 	}
 	return &resp, nil
 }
+
+func (p *Policy) Schedules(class string) ([]*state.ScheduleWindow, error) {
+	var resp []*state.ScheduleWindow
+	err := p.client.get("/v1/policy/"+class+"/schedules", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Plan performs a dry-run evaluation of the class scaling policy. If the passed policy is nil, the
+// stored policy of the class is evaluated.
+func (p *Policy) Plan(class string, policy *ScalingPolicy) (*state.EvaluationRecord, error) {
+	var in interface{}
+	if policy != nil {
+		in = policy
+	}
+
+	var resp state.EvaluationRecord
+	if err := p.client.post("/v1/policy/"+class+"/evaluate", in, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -1,6 +1,9 @@
 package api
 
-import metrics "github.com/armon/go-metrics"
+import (
+	metrics "github.com/armon/go-metrics"
+	"github.com/jrasell/chemtrail/pkg/state"
+)
 
 type System struct {
 	client *Client
@@ -32,3 +35,44 @@ func (s *System) Metrics() (*metrics.MetricsSummary, error) {
 	}
 	return &resp, nil
 }
+
+// AutoscalerStatus returns the current status of the autoscaler, including any active pause.
+func (s *System) AutoscalerStatus() (*state.AutoscalerStatus, error) {
+	var resp state.AutoscalerStatus
+	err := s.client.get("/v1/system/autoscaler", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PauseAutoscaler pauses the autoscaler for the duration in seconds. A duration of 0 pauses the
+// autoscaler until it is resumed.
+func (s *System) PauseAutoscaler(duration int, reason string) (*state.AutoscalerStatus, error) {
+	req := state.AutoscalerPauseRequest{Duration: duration, Reason: reason}
+
+	var resp state.AutoscalerStatus
+	if err := s.client.post("/v1/system/autoscaler/pause", &req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResumeAutoscaler removes any pause of the autoscaler.
+func (s *System) ResumeAutoscaler() (*state.AutoscalerStatus, error) {
+	var resp state.AutoscalerStatus
+	if err := s.client.post("/v1/system/autoscaler/resume", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Leader returns the current leader of the Chemtrail servers.
+func (s *System) Leader() (*state.LeaderStatus, error) {
+	var resp state.LeaderStatus
+	err := s.client.get("/v1/system/leader", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
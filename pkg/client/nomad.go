@@ -1,17 +1,31 @@
 package client
 
 import (
+	"crypto/tls"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/nomad/api"
 )
 
+const (
+	// statsClientTimeout is the time within which each request made by the stats client must
+	// complete.
+	statsClientTimeout = 10 * time.Second
+)
+
 // Nomad is a wrapper around the Nomad client which includes the current nodeID if found. This
 // allows the Chemtrail server to protect the node it is running on from scale in activities which
 // would cause undesirable situations.
 type Nomad struct {
 	Client *api.Client
 	NodeID string
+
+	// StatsClient is a Nomad client whose requests are bounded by a timeout. It is used to query
+	// the resource usage of allocations, and must not be used for blocking queries.
+	StatsClient *api.Client
 }
 
 // NewNomadClient builds the reusable Nomad client.
@@ -26,12 +40,38 @@ func NewNomadClient() (*Nomad, error) {
 		return nil, err
 	}
 
+	stats, err := newStatsClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Nomad{
-		Client: c,
-		NodeID: id,
+		Client:      c,
+		NodeID:      id,
+		StatsClient: stats,
 	}, nil
 }
 
+// newStatsClient builds a Nomad client using the default config, whose HTTP client times out
+// requests which do not complete within the statsClientTimeout.
+func newStatsClient() (*api.Client, error) {
+	cfg := api.DefaultConfig()
+
+	httpClient := cleanhttp.DefaultClient()
+	httpClient.Timeout = statsClientTimeout
+
+	transport := httpClient.Transport.(*http.Transport)
+	transport.TLSHandshakeTimeout = 10 * time.Second
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if err := api.ConfigureTLS(httpClient, cfg.TLSConfig); err != nil {
+		return nil, err
+	}
+	cfg.HttpClient = httpClient
+
+	return api.NewClient(cfg)
+}
+
 // chemtrailNodeID attempts to find the Nomad nodeID Chemtrail is running.
 func chemtrailNodeID(client *api.Client) (string, error) {
 	if envVar := os.Getenv("NOMAD_ALLOC_ID"); envVar == "" {
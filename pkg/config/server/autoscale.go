@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -8,26 +9,68 @@ import (
 const (
 	configKeyAutoscalerThreadNumberDefault       = 3
 	configKeyAutoscalerEvaluationIntervalDefault = 180
+	configKeyAutoscalerEvaluationJitterDefault   = 10
+	configKeyAutoscalerSampleIntervalDefault     = 10
+	configKeyAutoscalerSampleRetentionDefault    = 900
+	configKeyAutoscalerUsageIntervalDefault      = 30
 
 	configKeyAutoscalerEnabled            = "autoscaler-enabled"
 	configKeyAutoscalerEvaluationInterval = "autoscaler-evaluation-interval"
+	configKeyAutoscalerEvaluationJitter   = "autoscaler-evaluation-jitter"
 	configKeyAutoscalerThreadNumber       = "autoscaler-num-threads"
+	configKeyAutoscalerSampleInterval     = "autoscaler-sample-interval"
+	configKeyAutoscalerSampleRetention    = "autoscaler-sample-retention"
+	configKeyAutoscalerUsageEnabled       = "autoscaler-usage-enabled"
+	configKeyAutoscalerUsageInterval      = "autoscaler-usage-interval"
 )
 
 type AutoscalerConfig struct {
-	Enabled  bool
-	Interval int
-	Threads  int
+	Enabled         bool
+	Interval        int
+	Jitter          int
+	Threads         int
+	SampleInterval  int
+	SampleRetention int
+	UsageEnabled    bool
+	UsageInterval   int
 }
 
 func GetAutoscalerConfig() *AutoscalerConfig {
 	return &AutoscalerConfig{
-		Enabled:  viper.GetBool(configKeyAutoscalerEnabled),
-		Interval: viper.GetInt(configKeyAutoscalerEvaluationInterval),
-		Threads:  viper.GetInt(configKeyAutoscalerThreadNumber),
+		Enabled:         viper.GetBool(configKeyAutoscalerEnabled),
+		Interval:        viper.GetInt(configKeyAutoscalerEvaluationInterval),
+		Jitter:          viper.GetInt(configKeyAutoscalerEvaluationJitter),
+		Threads:         viper.GetInt(configKeyAutoscalerThreadNumber),
+		SampleInterval:  viper.GetInt(configKeyAutoscalerSampleInterval),
+		SampleRetention: viper.GetInt(configKeyAutoscalerSampleRetention),
+		UsageEnabled:    viper.GetBool(configKeyAutoscalerUsageEnabled),
+		UsageInterval:   viper.GetInt(configKeyAutoscalerUsageInterval),
 	}
 }
 
+// Validate checks the AutoscalerConfig contains sensible intervals. The resource sampler is run
+// regardless of whether the autoscaler is enabled, therefore the sample params are always checked.
+// The evaluation and usage intervals are only checked when the autoscaler and usage collector are
+// enabled respectively.
+func (c *AutoscalerConfig) Validate() error {
+	if c.SampleInterval < 1 {
+		return errors.New("autoscaler sample interval must be greater than 0")
+	}
+
+	if c.SampleRetention < c.SampleInterval {
+		return errors.New("autoscaler sample retention must not be less than the sample interval")
+	}
+
+	if c.Enabled && c.Interval < 1 {
+		return errors.New("autoscaler evaluation interval must be greater than 0")
+	}
+
+	if c.UsageEnabled && c.UsageInterval < 1 {
+		return errors.New("autoscaler usage interval must be greater than 0")
+	}
+	return nil
+}
+
 func RegisterAutoscalerConfig(cmd *cobra.Command) {
 	flags := cmd.PersistentFlags()
 
@@ -49,7 +92,20 @@ func RegisterAutoscalerConfig(cmd *cobra.Command) {
 			key          = configKeyAutoscalerEvaluationInterval
 			longOpt      = "autoscaler-evaluation-interval"
 			defaultValue = configKeyAutoscalerEvaluationIntervalDefault
-			description  = "The time period in seconds between autoscaling evaluation runs"
+			description  = "The default time period in seconds between autoscaling evaluations of each policy"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAutoscalerEvaluationJitter
+			longOpt      = "autoscaler-evaluation-jitter"
+			defaultValue = configKeyAutoscalerEvaluationJitterDefault
+			description  = "The maximum percentage of the evaluation interval by which policy evaluations are randomly offset"
 		)
 
 		flags.Int(longOpt, defaultValue, description)
@@ -69,4 +125,56 @@ func RegisterAutoscalerConfig(cmd *cobra.Command) {
 		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
 		viper.SetDefault(key, defaultValue)
 	}
+
+	{
+		const (
+			key          = configKeyAutoscalerSampleInterval
+			longOpt      = "autoscaler-sample-interval"
+			defaultValue = configKeyAutoscalerSampleIntervalDefault
+			description  = "The time period in seconds between class resource samples being taken"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAutoscalerSampleRetention
+			longOpt      = "autoscaler-sample-retention"
+			defaultValue = configKeyAutoscalerSampleRetentionDefault
+			description  = "The time period in seconds for which class resource samples are retained"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAutoscalerUsageEnabled
+			longOpt      = "autoscaler-usage-enabled"
+			defaultValue = false
+			description  = "Enable collection of the actual resource usage of allocations from Nomad clients"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAutoscalerUsageInterval
+			longOpt      = "autoscaler-usage-interval"
+			defaultValue = configKeyAutoscalerUsageIntervalDefault
+			description  = "The time period in seconds between allocation resource usage being collected"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
 }
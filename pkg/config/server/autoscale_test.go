@@ -1 +1,75 @@
 package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoscalerConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		inputConfig *AutoscalerConfig
+		expectError bool
+		name        string
+	}{
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900},
+			expectError: false,
+			name:        "valid config",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 0, SampleRetention: 900},
+			expectError: true,
+			name:        "zero sample interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: -10, SampleRetention: 900},
+			expectError: true,
+			name:        "negative sample interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 5},
+			expectError: true,
+			name:        "sample retention less than interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900, Enabled: true, Interval: 180},
+			expectError: false,
+			name:        "valid evaluation interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900, Enabled: true},
+			expectError: true,
+			name:        "zero evaluation interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900, Interval: -1},
+			expectError: false,
+			name:        "invalid evaluation interval with autoscaler disabled",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900, UsageEnabled: true, UsageInterval: 30},
+			expectError: false,
+			name:        "valid usage interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900, UsageEnabled: true},
+			expectError: true,
+			name:        "zero usage interval",
+		},
+		{
+			inputConfig: &AutoscalerConfig{SampleInterval: 10, SampleRetention: 900, UsageInterval: -1},
+			expectError: false,
+			name:        "invalid usage interval with usage disabled",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputConfig.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	configKeyMetricSourcePrometheusAddress = "metric-source-prometheus-address"
+)
+
+// MetricSourceConfig is the server metric source configuration struct.
+type MetricSourceConfig struct {
+	PrometheusAddr string
+}
+
+// MarshalZerologObject is the Zerolog marshaller which allow us to log the
+// object.
+func (c *MetricSourceConfig) MarshalZerologObject(e *zerolog.Event) {
+	e.Str(configKeyMetricSourcePrometheusAddress, c.PrometheusAddr)
+}
+
+// GetMetricSourceConfig hydrates the metric source config struct.
+func GetMetricSourceConfig() *MetricSourceConfig {
+	return &MetricSourceConfig{
+		PrometheusAddr: viper.GetString(configKeyMetricSourcePrometheusAddress),
+	}
+}
+
+// RegisterMetricSourceConfig is used by a Cobra command to register the metric
+// source CLI flags.
+func RegisterMetricSourceConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key          = configKeyMetricSourcePrometheusAddress
+			longOpt      = "metric-source-prometheus-address"
+			defaultValue = ""
+			description  = "The address of the Prometheus server used by prometheus check sources"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+}
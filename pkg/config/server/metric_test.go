@@ -0,0 +1,16 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MetricSourceConfig(t *testing.T) {
+	fakeCMD := &cobra.Command{}
+	RegisterMetricSourceConfig(fakeCMD)
+
+	cfg := GetMetricSourceConfig()
+	assert.Equal(t, "", cfg.PrometheusAddr)
+}
@@ -11,22 +11,29 @@ const (
 	configKeyBindPortDefault = 8000
 	configKeyBindAddr        = "bind-addr"
 	configKeyBindPort        = "bind-port"
+	configKeyAdvertiseAddr   = "advertise-addr"
 )
 
 type Config struct {
 	Bind string
 	Port uint16
+
+	// AdvertiseAddr is the HTTP(S) address of the server advertised to other Chemtrail servers.
+	// If empty, the address is built from the bind address and port.
+	AdvertiseAddr string
 }
 
 func (c *Config) MarshalZerologObject(e *zerolog.Event) {
 	e.Str(configKeyBindAddr, c.Bind).
-		Uint16(configKeyBindPort, c.Port)
+		Uint16(configKeyBindPort, c.Port).
+		Str(configKeyAdvertiseAddr, c.AdvertiseAddr)
 }
 
 func GetConfig() Config {
 	return Config{
-		Bind: viper.GetString(configKeyBindAddr),
-		Port: uint16(viper.GetInt(configKeyBindPort)),
+		Bind:          viper.GetString(configKeyBindAddr),
+		Port:          uint16(viper.GetInt(configKeyBindPort)),
+		AdvertiseAddr: viper.GetString(configKeyAdvertiseAddr),
 	}
 }
 
@@ -58,4 +65,17 @@ func RegisterConfig(cmd *cobra.Command) {
 		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
 		viper.SetDefault(key, defaultValue)
 	}
+
+	{
+		const (
+			key          = configKeyAdvertiseAddr
+			longOpt      = "advertise-addr"
+			defaultValue = ""
+			description  = "The HTTP(S) address advertised to other Chemtrail servers, defaults to the bind address and port"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
 }
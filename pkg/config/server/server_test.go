@@ -14,4 +14,5 @@ func Test_ServerConfig(t *testing.T) {
 	cfg := GetConfig()
 	assert.Equal(t, configKeyBindAddrDefault, cfg.Bind)
 	assert.Equal(t, uint16(configKeyBindPortDefault), cfg.Port)
+	assert.Equal(t, "", cfg.AdvertiseAddr)
 }
@@ -0,0 +1,168 @@
+package consul
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/chemtrail/pkg/leader"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// baseLeaderKVPath is the Consul path suffix added to the CLI param which identifies the key
+	// used as the leadership lock.
+	baseLeaderKVPath = "leader"
+
+	// sessionName is the name of the Consul session used to hold the leadership lock.
+	sessionName = "chemtrail-leader"
+
+	// sessionTTL is the TTL of the Consul session used to hold the leadership lock. If the leader
+	// is unable to renew the session within the TTL, leadership is lost.
+	sessionTTL = "15s"
+
+	// monitorRetries is the number of times monitoring of a held lock is retried on Consul errors
+	// before leadership is considered lost.
+	monitorRetries = 5
+
+	// retryInterval is the time period waited before campaigning again following an error.
+	retryInterval = 5 * time.Second
+)
+
+// Elector is the Consul implementation of the leader.Elector interface. Leadership is held using
+// a Consul lock, which is backed by a session and released should the leader fail.
+type Elector struct {
+	addr     string
+	key      string
+	client   *api.Client
+	logger   zerolog.Logger
+	isLeader bool
+	l        sync.RWMutex
+	stopChan chan struct{}
+
+	// doneChan is closed once Run has returned.
+	doneChan chan struct{}
+}
+
+// NewElector returns the Consul implementation of the leader.Elector interface. The address is
+// the advertised address of this server, which is stored within the lock while it is held.
+func NewElector(logger zerolog.Logger, addr, path string, client *api.Client) leader.Elector {
+	return &Elector{
+		addr:     addr,
+		key:      path + baseLeaderKVPath,
+		client:   client,
+		logger:   logger.With().Str("component", "leader-election").Logger(),
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}
+}
+
+// Run satisfies the Run function on the leader.Elector interface.
+func (e *Elector) Run(onGain, onLoss func()) {
+	defer close(e.doneChan)
+
+	e.logger.Info().Str("key", e.key).Msg("starting Consul leader election")
+
+	for {
+		lock, err := e.client.LockOpts(&api.LockOptions{
+			Key:            e.key,
+			Value:          []byte(e.addr),
+			SessionName:    sessionName,
+			SessionTTL:     sessionTTL,
+			MonitorRetries: monitorRetries,
+		})
+		if err != nil {
+			e.logger.Error().Err(err).Msg("failed to setup leadership lock")
+			if e.waitRetry() {
+				return
+			}
+			continue
+		}
+
+		// Block until leadership is acquired, or the elector is stopped in which case the
+		// returned channel is nil.
+		lostChan, err := lock.Lock(e.stopChan)
+		if err != nil {
+			e.logger.Error().Err(err).Msg("failed to acquire leadership lock")
+			if e.waitRetry() {
+				return
+			}
+			continue
+		}
+		if lostChan == nil {
+			return
+		}
+
+		e.logger.Info().Msg("acquired leadership")
+		e.setLeader(true)
+		onGain()
+
+		stopped := false
+
+		select {
+		case <-lostChan:
+			e.logger.Warn().Msg("lost leadership")
+		case <-e.stopChan:
+			e.logger.Info().Msg("releasing leadership as a result of shutdown request")
+			stopped = true
+		}
+
+		e.setLeader(false)
+		onLoss()
+
+		// Unlock the lock to stop the session renewal and, if still held, release leadership so
+		// that another server can take over without waiting for the session TTL.
+		if err := lock.Unlock(); err != nil && err != api.ErrLockNotHeld {
+			e.logger.Warn().Err(err).Msg("failed to release leadership lock")
+		}
+
+		if stopped {
+			return
+		}
+	}
+}
+
+// Stop satisfies the Stop function on the leader.Elector interface.
+func (e *Elector) Stop() {
+	close(e.stopChan)
+	<-e.doneChan
+}
+
+// IsLeader satisfies the IsLeader function on the leader.Elector interface.
+func (e *Elector) IsLeader() bool {
+	e.l.RLock()
+	defer e.l.RUnlock()
+	return e.isLeader
+}
+
+// Leader satisfies the Leader function on the leader.Elector interface.
+func (e *Elector) Leader() (string, error) {
+	kv, _, err := e.client.KV().Get(e.key, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// The lock key remains once released, but without a session, which indicates no leader is
+	// currently elected.
+	if kv == nil || kv.Session == "" {
+		return "", nil
+	}
+	return string(kv.Value), nil
+}
+
+func (e *Elector) setLeader(isLeader bool) {
+	e.l.Lock()
+	e.isLeader = isLeader
+	e.l.Unlock()
+}
+
+// waitRetry waits for the retry interval, returning true if the elector was stopped during the
+// wait.
+func (e *Elector) waitRetry() bool {
+	select {
+	case <-e.stopChan:
+		return true
+	case <-time.After(retryInterval):
+		return false
+	}
+}
@@ -0,0 +1,24 @@
+package leader
+
+// Elector is the interface which leader election implementations must satisfy. When running
+// multiple Chemtrail servers, only the elected leader runs the autoscaler and state maintenance
+// processes, while the other servers stand by ready to take over.
+type Elector interface {
+
+	// Run campaigns for leadership until Stop is called. The onGain function is called once
+	// leadership has been acquired, and the onLoss function once it has subsequently been lost,
+	// including when the elector is stopped. Both functions are called synchronously, therefore
+	// leadership is not released until onLoss has returned.
+	Run(onGain, onLoss func())
+
+	// Stop ends the campaign, releasing leadership if it is held. Stop blocks until Run has
+	// returned, and therefore must only be called once Run has been started.
+	Stop()
+
+	// IsLeader returns whether this server currently holds leadership.
+	IsLeader() bool
+
+	// Leader returns the advertised address of the current leader. An empty string indicates no
+	// leader is currently elected.
+	Leader() (string, error)
+}
@@ -0,0 +1,68 @@
+package local
+
+import (
+	"sync"
+
+	"github.com/jrasell/chemtrail/pkg/leader"
+)
+
+// Elector is the local implementation of the leader.Elector interface. It is used when Chemtrail
+// state is not shared between servers, and therefore the server is always the leader.
+type Elector struct {
+	addr     string
+	isLeader bool
+	l        sync.RWMutex
+	stopChan chan struct{}
+
+	// doneChan is closed once Run has returned.
+	doneChan chan struct{}
+}
+
+// NewElector returns the local implementation of the leader.Elector interface.
+func NewElector(addr string) leader.Elector {
+	return &Elector{
+		addr:     addr,
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}
+}
+
+// Run satisfies the Run function on the leader.Elector interface.
+func (e *Elector) Run(onGain, onLoss func()) {
+	defer close(e.doneChan)
+
+	e.setLeader(true)
+	onGain()
+
+	<-e.stopChan
+
+	e.setLeader(false)
+	onLoss()
+}
+
+// Stop satisfies the Stop function on the leader.Elector interface.
+func (e *Elector) Stop() {
+	close(e.stopChan)
+	<-e.doneChan
+}
+
+// IsLeader satisfies the IsLeader function on the leader.Elector interface.
+func (e *Elector) IsLeader() bool {
+	e.l.RLock()
+	defer e.l.RUnlock()
+	return e.isLeader
+}
+
+// Leader satisfies the Leader function on the leader.Elector interface.
+func (e *Elector) Leader() (string, error) {
+	if !e.IsLeader() {
+		return "", nil
+	}
+	return e.addr, nil
+}
+
+func (e *Elector) setLeader(isLeader bool) {
+	e.l.Lock()
+	e.isLeader = isLeader
+	e.l.Unlock()
+}
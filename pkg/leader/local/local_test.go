@@ -0,0 +1,31 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElector(t *testing.T) {
+	e := NewElector("http://127.0.0.1:8000")
+
+	gained := make(chan struct{})
+	lost := false
+
+	go e.Run(func() { close(gained) }, func() { lost = true })
+	<-gained
+
+	assert.True(t, e.IsLeader())
+	addr, err := e.Leader()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://127.0.0.1:8000", addr)
+
+	// Stop blocks until the loss of leadership has been handled.
+	e.Stop()
+
+	assert.True(t, lost)
+	assert.False(t, e.IsLeader())
+	addr, err = e.Leader()
+	assert.Nil(t, err)
+	assert.Equal(t, "", addr)
+}
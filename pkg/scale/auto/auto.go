@@ -1,12 +1,17 @@
 package auto
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jrasell/chemtrail/pkg/client"
 	"github.com/jrasell/chemtrail/pkg/helper"
 	"github.com/jrasell/chemtrail/pkg/scale"
+	"github.com/jrasell/chemtrail/pkg/scale/metric"
 	"github.com/jrasell/chemtrail/pkg/scale/resource"
 	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/panjf2000/ants"
@@ -16,33 +21,73 @@ import (
 type Scale struct {
 	threads  int
 	interval int
+	jitter   int
 	logger   zerolog.Logger
 	nomad    *client.Nomad
 
 	policyBackend   state.PolicyBackend
+	scaleBackend    state.ScaleBackend
+	historyBackend  state.CheckHistoryBackend
+	pauseBackend    state.PauseBackend
 	scaler          scale.Scale
 	resourceHandler resource.Handler
+	metricSources   map[state.MetricSource]metric.Source
 	pool            *ants.PoolWithFunc
-	inProgress      bool
+
+	// inProgress tracks the classes which currently have an evaluation in progress, so that a
+	// class is not evaluated again until the previous evaluation has finished.
+	inProgress     map[string]bool
+	inProgressLock sync.Mutex
+
+	// schedulers tracks the classes which have a running policy scheduler.
+	schedulers     map[string]bool
+	schedulersLock sync.Mutex
+	schedulersWG   sync.WaitGroup
+
+	// rand is the random source used to jitter policy evaluations.
+	rand     *rand.Rand
+	randLock sync.Mutex
+
+	// dryRun indicates the Scale is being used to plan an evaluation, rather than perform one.
+	// Dry-run evaluations do not emit telemetry.
+	dryRun bool
 
 	// isRunning is used to track whether the autoscaler loop is being run. This helps determine
 	// whether stop should be called.
 	isRunning bool
 
-	// doneChan is used to stop the autoscaling execution.
-	doneChan chan struct{}
+	// doneChan is used to stop the autoscaling execution, and stoppedChan is closed once the
+	// autoscaling loop has exited. Both are created each time the autoscaler is run, allowing it
+	// to be stopped and run again as the server gains and loses leadership.
+	doneChan    chan struct{}
+	stoppedChan chan struct{}
+
+	// ctx is passed to the scaling activities triggered by the autoscaler, and is cancelled when
+	// the autoscaler is stopped so that in-flight activities are aborted.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// runLock protects isRunning, doneChan, stoppedChan, ctx and cancel.
+	runLock sync.RWMutex
 }
 
 func NewAutoScaler(cfg *Config) (*Scale, error) {
 	s := Scale{
 		threads:         cfg.Threads,
 		interval:        cfg.Interval,
+		jitter:          cfg.Jitter,
 		logger:          cfg.Logger,
 		nomad:           cfg.Nomad,
 		policyBackend:   cfg.Policy,
+		scaleBackend:    cfg.State,
+		historyBackend:  cfg.History,
+		pauseBackend:    cfg.Pause,
 		scaler:          cfg.Scale,
 		resourceHandler: cfg.Resource,
-		doneChan:        make(chan struct{}),
+		metricSources:   cfg.MetricSources,
+		inProgress:      make(map[string]bool),
+		schedulers:      make(map[string]bool),
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 
 	pool, err := s.createWorkerPool()
@@ -54,82 +99,78 @@ func NewAutoScaler(cfg *Config) (*Scale, error) {
 	return &s, nil
 }
 
+// Run starts the autoscaling loop in the background. If the autoscaler is already running, the call
+// is a no-op.
 func (s *Scale) Run() {
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+
+	if s.isRunning {
+		return
+	}
+
 	s.logger.Info().Msg("starting Chemtrail internal auto-scaling engine")
 
 	// Track that the autoscaler is actively running.
 	s.isRunning = true
+	s.doneChan = make(chan struct{})
+	s.stoppedChan = make(chan struct{})
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	go s.runLoop(s.doneChan, s.stoppedChan)
+}
+
+// runLoop runs the autoscaling loop until the doneChan is closed, closing the stoppedChan once all
+// policy schedulers have exited.
+func (s *Scale) runLoop(doneChan, stoppedChan chan struct{}) {
+	defer close(stoppedChan)
 
-	t := time.NewTicker(time.Second * time.Duration(s.interval))
+	// Each policy is evaluated by its own scheduler, using the policy evaluation interval. The
+	// policies are periodically listed, so that schedulers are started for newly written
+	// policies.
+	s.runPolicyDiscovery(doneChan)
+
+	t := time.NewTicker(policyDiscoveryInterval)
 	defer t.Stop()
 
 	for {
 		select {
 		case <-t.C:
-			s.logger.Info().Msg("triggering new autoscaler evaluation run")
-
-			// Check whether a previous scaling loop is in progress, and if it is we should skip
-			// this round. This avoids putting more pressure on a system which may be under load
-			// causing slow API responses.
-			if s.inProgress {
-				s.logger.Info().Msg("scaling run in progress, skipping new assessment")
-				break
-			}
-			s.setScalingInProgressTrue()
-
-			policies, err := s.policyBackend.GetPolicies()
-			if err != nil {
-				s.logger.Error().Err(err).Msg("autoscaler unable to get scaling policies")
-				s.setScalingInProgressFalse()
-				break
-			}
-
-			if len(policies) == 0 {
-				s.logger.Debug().Msg("no scaling policies found in storage backend for autoscaler to iterate")
-				s.setScalingInProgressFalse()
-				break
-			}
-
-			for _, policy := range policies {
-
-				// Check whether the policy is enabled.
-				if !policy.Enabled {
-					continue
-				}
-
-				if err := s.pool.Invoke(policy); err != nil {
-					s.logger.Error().Err(err).Msg("failed to invoke autoscaling worker thread")
-				}
-			}
-			s.setScalingInProgressFalse()
-
-		case <-s.doneChan:
-			s.isRunning = false
+			s.runPolicyDiscovery(doneChan)
+
+		case <-doneChan:
+			s.schedulersWG.Wait()
 			return
 		}
 	}
 }
 
-// Stop is used to gracefully stop the autoscaling workers.
+// Stop is used to stop the autoscaling workers. In-flight scaling activities are aborted rather
+// than waited upon, as they can run for a long time and must not continue to alter the cluster
+// once another server may be scaling the same classes. Once stopped, the autoscaler can be run
+// again.
 func (s *Scale) Stop() {
+	s.runLock.Lock()
+	if !s.isRunning {
+		s.runLock.Unlock()
+		return
+	}
 
-	// Inform sub-process to exit.
+	// Inform sub-process to exit, and abort any in-flight scaling activities.
+	s.isRunning = false
 	close(s.doneChan)
+	s.cancel()
+	stoppedChan := s.stoppedChan
+	s.runLock.Unlock()
 
-	for {
-		if !s.isRunning && !s.inProgress {
-			s.pool.Release()
-			s.logger.Info().Msg("successfully drained autoscaler worker pool")
-			return
-		}
-		s.logger.Debug().Msg("autoscaler still has in-flight workers, will continue to check")
-		time.Sleep(1 * time.Second)
+	<-stoppedChan
+
+	if n := s.evaluationsInProgress(); n > 0 {
+		s.logger.Info().Int("in-flight", n).Msg("aborted in-flight autoscaler evaluations")
 	}
+	s.logger.Info().Msg("successfully stopped autoscaler")
 }
 
-func (s *Scale) setScalingInProgressTrue()  { s.inProgress = true }
-func (s *Scale) setScalingInProgressFalse() { s.inProgress = false }
-
 // createWorkerPool is responsible for building the ants goroutine worker pool with the number of
 // threads controlled by the operator configured value.
 func (s *Scale) createWorkerPool() (*ants.PoolWithFunc, error) {
@@ -138,51 +179,146 @@ func (s *Scale) createWorkerPool() (*ants.PoolWithFunc, error) {
 
 func (s *Scale) workerPoolFunc() func(payload interface{}) {
 	return func(payload interface{}) {
-
-		// If this thread starts after the autoscaler has been asked to shutdown, exit. Otherwise
-		// perform the work.
-		select {
-		case <-s.doneChan:
-			s.logger.Debug().Msg("exiting autoscaling thread as a result of shutdown request")
+		req, ok := payload.(*state.ClientScalingPolicy)
+		if !ok {
+			s.logger.Error().Msg("autoscaler worker pool received unexpected payload type")
 			return
-		default:
 		}
+		defer s.finishEvaluation(req.Class)
 
-		req, ok := payload.(*state.ClientScalingPolicy)
+		// If this thread starts after the autoscaler has been asked to shutdown, exit. Otherwise
+		// perform the work.
+		ctx, ok := s.runContext()
 		if !ok {
-			s.logger.Error().Msg("autoscaler worker pool received unexpected payload type")
+			s.logger.Debug().Msg("exiting autoscaling thread as a result of shutdown request")
 			return
 		}
 
 		// Create a temporary logger so that every log line includes the targeted class.
 		logger := helper.LoggerWithNodeClassContext(s.logger, req.Class)
 
-		scalingDecision, err := s.performPolicyChecks(logger, req)
-		if err != nil {
-			logger.Error().Err(err).Msg("unable to perform node class scaling decision")
+		// Every evaluation produces a record, which is written once the evaluation has finished
+		// regardless of the outcome.
+		rec := s.newEvaluationRecord(req.Class)
+
+		scalingReq := s.evaluate(logger, req, rec)
+		if scalingReq == nil {
+			s.writeEvaluationRecord(logger, rec)
 			return
 		}
-		if scalingDecision == nil {
-			logger.Debug().Msg("no scaling action required")
-			return
+
+		// The scaling activity will alter the class, therefore the check history is reset so
+		// that subsequent decisions are based on evaluations of the updated class.
+		if err := s.historyBackend.DeleteCheckHistory(req.Class); err != nil {
+			logger.Error().Err(err).Msg("failed to reset node class check history")
 		}
 
-		// Generate a UUID which is used as the scaling identifier. If we can't generate this then
-		// we exit.
-		id, err := uuid.NewV4()
+		// The record is written before the activity is invoked, as the activity can run for a
+		// long time and the decision which triggered it should be visible while it does. The
+		// outcome of the activity is recorded by the activity itself.
+		rec.ActivityID = scalingReq.ID.String()
+		rec.SetOutcome(state.EvaluationOutcomeScaling, "",
+			fmt.Sprintf("triggered scale %s activity", scalingReq.Direction))
+		s.writeEvaluationRecord(logger, rec)
+
+		s.scaler.InvokeScaling(ctx, scalingReq)
+	}
+}
+
+// evaluate runs the scaling evaluation of the policy, recording the result within the passed
+// record. If the evaluation decides scaling is required and the request passes the scale backend
+// preconditions, the scaling request is returned ready to be invoked.
+func (s *Scale) evaluate(logger zerolog.Logger, req *state.ClientScalingPolicy, rec *state.EvaluationRecord) *state.ScalingRequest {
+	rec.Strategy = req.GetStrategy()
+
+	// Check whether the class is within a cooldown period following a previous scaling
+	// activity. If it is, the evaluation is skipped to allow the cluster to settle.
+	remaining, err := s.classCooldownRemaining(req)
+	if err != nil {
+		logger.Error().Err(err).Msg("unable to determine node class cooldown status")
+		rec.SetOutcome(state.EvaluationOutcomeFailed, "", err.Error())
+		return nil
+	}
+	if remaining > 0 {
+		s.recordSkippedEvaluation(logger, rec, skipReasonCooldown,
+			fmt.Sprintf("class is within cooldown period, %v remaining", remaining.Round(time.Second)))
+		return nil
+	}
+
+	// Apply the capacity overrides of any active schedule window to the policy used for this
+	// evaluation.
+	window, err := req.GetActiveScheduleWindow(time.Now())
+	if err != nil {
+		logger.Error().Err(err).Msg("unable to determine node class schedule window")
+		rec.SetOutcome(state.EvaluationOutcomeFailed, "", err.Error())
+		return nil
+	}
+	pol, desired := req.WithScheduleWindow(window)
+	if window != nil {
+		rec.Schedule = window.Name
+		logger.Debug().
+			Str("schedule-name", window.Name).
+			Int("schedule-min-count", pol.MinCount).
+			Int("schedule-max-count", pol.MaxCount).
+			Int("schedule-desired-count", desired).
+			Msg("node class schedule window active")
+	}
+
+	// Enforcing the scheduled capacity takes priority over the policy checks. When the window has
+	// a desired count, the class is held at that count and the checks are not run, otherwise they
+	// would scale the class away from the desired count only for the next evaluation to scale it
+	// back.
+	scalingDecision := scheduledDecision(window, pol, desired, len(s.resourceHandler.GetNodesOfClass(req.Class)))
+	if scalingDecision == nil && desired == 0 {
+		scalingDecision, err = s.performStrategy(logger, pol, rec)
 		if err != nil {
-			logger.Error().Err(err).Msg("failed to generate scaling ID")
-			return
+			logger.Error().Err(err).Msg("unable to perform node class scaling decision")
+			rec.SetOutcome(state.EvaluationOutcomeFailed, "", err.Error())
+			return nil
 		}
+	}
+	if scalingDecision == nil {
+		logger.Debug().Msg("no scaling action required")
+		rec.SetOutcome(state.EvaluationOutcomeNoAction, "", "no scaling action required")
+		return nil
+	}
+	rec.Direction = scalingDecision.direction
+	rec.Count = scalingDecision.count
 
-		scalingReq := state.ScalingRequest{ID: id, Direction: scalingDecision.direction, Policy: req}
-		if _, err := s.scaler.OKToScale(&scalingReq); err != nil {
-			logger.Info().Str("reason", err.Error()).Msg("autoscaling activity not allowed to continue")
-			return
-		}
-		s.scaler.InvokeScaling(&scalingReq)
+	// Generate a UUID which is used as the scaling identifier. If we can't generate this then
+	// we exit.
+	id, err := uuid.NewV4()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to generate scaling ID")
+		rec.SetOutcome(state.EvaluationOutcomeFailed, "", err.Error())
+		return nil
+	}
+
+	scalingReq := state.ScalingRequest{
+		ID:        id,
+		Direction: scalingDecision.direction,
+		Count:     scalingDecision.count,
+		Policy:    pol,
 	}
+	if _, err := s.scaler.OKToScale(&scalingReq); err != nil {
+		s.recordSkippedEvaluation(logger, rec, skipReasonPrecondition, err.Error())
+		return nil
+	}
+	rec.Count = scalingReq.GetCount()
+	return &scalingReq
 }
 
 // IsRunning is used to determine if the autoscaler loop is running.
-func (s *Scale) IsRunning() bool { return s.isRunning }
+func (s *Scale) IsRunning() bool {
+	s.runLock.RLock()
+	defer s.runLock.RUnlock()
+	return s.isRunning
+}
+
+// runContext returns the context of the current autoscaler run, and whether the autoscaler is
+// running.
+func (s *Scale) runContext() (context.Context, bool) {
+	s.runLock.RLock()
+	defer s.runLock.RUnlock()
+	return s.ctx, s.isRunning
+}
@@ -3,6 +3,7 @@ package auto
 import (
 	"github.com/jrasell/chemtrail/pkg/client"
 	"github.com/jrasell/chemtrail/pkg/scale"
+	"github.com/jrasell/chemtrail/pkg/scale/metric"
 	"github.com/jrasell/chemtrail/pkg/scale/resource"
 	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/rs/zerolog"
@@ -12,8 +13,19 @@ type Config struct {
 	Nomad    *client.Nomad
 	Logger   zerolog.Logger
 	Policy   state.PolicyBackend
+	State    state.ScaleBackend
+	History  state.CheckHistoryBackend
+	Pause    state.PauseBackend
 	Resource resource.Handler
 	Scale    scale.Scale
 	Interval int
 	Threads  int
+
+	// Jitter is the maximum percentage of the evaluation interval by which policy evaluations
+	// are randomly offset.
+	Jitter int
+
+	// MetricSources contains the configured metric sources which provide check values, keyed by
+	// the source identifier.
+	MetricSources map[state.MetricSource]metric.Source
 }
@@ -0,0 +1,63 @@
+package auto
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// classCooldownRemaining uses the most recently completed scaling activity of the policy class to
+// determine how long remains of the cooldown period. A zero duration indicates the class is not
+// within a cooldown period and is free to be scaled.
+func (s *Scale) classCooldownRemaining(pol *state.ClientScalingPolicy) (time.Duration, error) {
+	if pol.ScaleOutCooldown == 0 && pol.ScaleInCooldown == 0 {
+		return 0, nil
+	}
+
+	activities, err := s.scaleBackend.GetScalingActivities()
+	if err != nil {
+		return 0, err
+	}
+	return cooldownRemaining(lastCompletedActivity(activities, pol.Class), pol, helper.GenerateEventTimestamp()), nil
+}
+
+// lastCompletedActivity iterates the scaling activities to find the most recently completed
+// activity of the class. If no completed activity is found, nil is returned.
+func lastCompletedActivity(activities map[uuid.UUID]*state.ScalingActivity, class string) *state.ScalingActivity {
+	var last *state.ScalingActivity
+
+	for _, activity := range activities {
+		if activity.Class != class || activity.Status != state.ScaleStatusCompleted {
+			continue
+		}
+		if last == nil || activity.LastUpdate > last.LastUpdate {
+			last = activity
+		}
+	}
+	return last
+}
+
+// cooldownRemaining calculates the remaining cooldown time based on the direction of the passed
+// activity and the policy cooldown configuration. The now parameter is a UnixNano timestamp.
+func cooldownRemaining(activity *state.ScalingActivity, pol *state.ClientScalingPolicy, now int64) time.Duration {
+	if activity == nil {
+		return 0
+	}
+
+	var cooldown int
+
+	switch activity.Direction {
+	case state.ScaleDirectionOut:
+		cooldown = pol.ScaleOutCooldown
+	case state.ScaleDirectionIn:
+		cooldown = pol.ScaleInCooldown
+	}
+
+	remaining := time.Duration(activity.LastUpdate + int64(cooldown)*int64(time.Second) - now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
@@ -0,0 +1,90 @@
+package auto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lastCompletedActivity(t *testing.T) {
+	completedOld := &state.ScalingActivity{Class: "test", Status: state.ScaleStatusCompleted, LastUpdate: 10}
+	completedNew := &state.ScalingActivity{Class: "test", Status: state.ScaleStatusCompleted, LastUpdate: 20}
+	failedNewest := &state.ScalingActivity{Class: "test", Status: state.ScaleStatusFailed, LastUpdate: 30}
+	otherClass := &state.ScalingActivity{Class: "other", Status: state.ScaleStatusCompleted, LastUpdate: 40}
+
+	testCases := []struct {
+		inputActivities map[uuid.UUID]*state.ScalingActivity
+		inputClass      string
+		expectedOutput  *state.ScalingActivity
+		name            string
+	}{
+		{
+			inputActivities: map[uuid.UUID]*state.ScalingActivity{},
+			inputClass:      "test",
+			expectedOutput:  nil,
+			name:            "no stored activities",
+		},
+		{
+			inputActivities: map[uuid.UUID]*state.ScalingActivity{
+				uuid.Must(uuid.NewV4()): completedOld,
+				uuid.Must(uuid.NewV4()): completedNew,
+				uuid.Must(uuid.NewV4()): failedNewest,
+				uuid.Must(uuid.NewV4()): otherClass,
+			},
+			inputClass:     "test",
+			expectedOutput: completedNew,
+			name:           "multiple activities across classes and statuses",
+		},
+		{
+			inputActivities: map[uuid.UUID]*state.ScalingActivity{uuid.Must(uuid.NewV4()): otherClass},
+			inputClass:      "test",
+			expectedOutput:  nil,
+			name:            "only activities of another class",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := lastCompletedActivity(tc.inputActivities, tc.inputClass)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_cooldownRemaining(t *testing.T) {
+	now := time.Now().UnixNano()
+	policy := &state.ClientScalingPolicy{ScaleOutCooldown: 300, ScaleInCooldown: 600}
+
+	testCases := []struct {
+		inputActivity  *state.ScalingActivity
+		expectedOutput time.Duration
+		name           string
+	}{
+		{
+			inputActivity:  nil,
+			expectedOutput: 0,
+			name:           "no previous activity",
+		},
+		{
+			inputActivity:  &state.ScalingActivity{Direction: state.ScaleDirectionOut, LastUpdate: now - int64(100*time.Second)},
+			expectedOutput: 200 * time.Second,
+			name:           "within scale out cooldown",
+		},
+		{
+			inputActivity:  &state.ScalingActivity{Direction: state.ScaleDirectionIn, LastUpdate: now - int64(100*time.Second)},
+			expectedOutput: 500 * time.Second,
+			name:           "within scale in cooldown",
+		},
+		{
+			inputActivity:  &state.ScalingActivity{Direction: state.ScaleDirectionOut, LastUpdate: now - int64(301*time.Second)},
+			expectedOutput: 0,
+			name:           "scale out cooldown expired",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := cooldownRemaining(tc.inputActivity, policy, now)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -1,26 +1,61 @@
 package auto
 
 import (
+	"github.com/jrasell/chemtrail/pkg/scale/metric"
 	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
 type decision struct {
 	direction state.ScaleDirection
+
+	// count is the number of nodes the decision requires to be added or removed. A value of 0
+	// uses the scaling policy count for the direction.
+	count int
+}
+
+// performStrategy runs the decision strategy configured on the policy, adding the result of each
+// check or target to the evaluation record.
+func (s *Scale) performStrategy(log zerolog.Logger, pol *state.ClientScalingPolicy, rec *state.EvaluationRecord) (*decision, error) {
+	switch pol.GetStrategy() {
+	case state.StrategyTargetTracking:
+		return s.performTargetTracking(log, pol, rec)
+	default:
+		return s.performPolicyChecks(log, pol, rec)
+	}
 }
 
-func (s *Scale) performPolicyChecks(log zerolog.Logger, pol *state.ClientScalingPolicy) (*decision, error) {
+func (s *Scale) performPolicyChecks(log zerolog.Logger, pol *state.ClientScalingPolicy, rec *state.EvaluationRecord) (*decision, error) {
+
+	// Create a decision mapping. This allows us to track the decisions made by the various checks
+	// and store information as desired to explain what is happening. The value is the number of
+	// nodes to scale by, where 0 indicates the count should be calculated by the scale backend.
+	classDecision := make(map[state.ScaleDirection]int)
+
+	// The current class size is used to calculate percentage based step adjustments.
+	current := len(s.resourceHandler.GetNodesOfClass(pol.Class))
 
-	// Gather the allocated resource stats for the node class.
-	allocStats, err := s.resourceHandler.GetClassResourceAllocation(pol.Class)
+	// Load the check history for the class so the outcomes of this evaluation can be combined
+	// with those of previous evaluations.
+	history, err := s.historyBackend.GetCheckHistory(pol.Class)
 	if err != nil {
 		return nil, err
 	}
+	if history == nil {
+		history = state.NewCheckHistory(pol.Class)
+	}
 
-	// Create a decision mapping. This allows us to track the decisions made by the various checks
-	// and store information as desired to explain what is happening. This is currently a boolean
-	// until the desired data is better understood.
-	classDecision := make(map[state.ScaleDirection]bool)
+	// Track the checks which are run, so that history of checks removed from the policy or
+	// disabled can be pruned.
+	activeChecks := make(map[string]bool)
+
+	// Track the checks which fire, so that they can be combined using the policy expressions.
+	firedChecks := make(map[string]bool)
+
+	// Track the checks whose value is unavailable, which are skipped rather than failing the
+	// evaluation.
+	unavailableChecks := make(map[string]bool)
 
 	for name, check := range pol.Checks {
 
@@ -30,49 +65,153 @@ func (s *Scale) performPolicyChecks(log zerolog.Logger, pol *state.ClientScaling
 				Msg("scaling policy check administratively disabled")
 			continue
 		}
+		activeChecks[name] = true
 
-		var actual float64
+		source, ok := s.metricSources[check.GetSource()]
+		if !ok {
+			return nil, errors.Errorf("metric source %s is not configured", check.GetSource().String())
+		}
 
-		switch check.Resource {
-		case state.ScaleResourceCPU:
-			actual = allocStats.CPU
-		case state.ScaleResourceMemory:
-			actual = allocStats.Memory
+		actual, err := source.Value(pol.Class, check)
+		if errors.Cause(err) == metric.ErrValueUnavailable {
+			log.Warn().
+				Err(err).
+				Str("check-name", name).
+				Msg("scaling policy check value unavailable, skipping check")
+			unavailableChecks[name] = true
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read value of check: "+name)
 		}
 		log.Debug().
 			Str("check-name", name).
+			Str("check-source", source.Name()).
 			Str("check-resource", check.Resource.String()).
 			Float64("check-resource-threshold", check.ComparisonPercentage).
 			Float64("check-resource-actual", actual).
 			Str("check-resource-comparison", check.ComparisonOperator.String()).
+			Int("check-aggregation-window", check.AggregationWindow).
 			Msg("performing scaling policy check analysis")
 
-		// Perform the actual check comparison and blindly add a class decision entry. This will
-		// change once we track decision metadata.
+		// Perform the actual check comparison and record the outcome within the check history.
 		checkDecision := s.performPolicyCheck(check, actual, check.ComparisonPercentage)
+		history.Record(name, checkDecision.direction != state.ScaleDirectionNone, check.GetEvaluationPeriods())
+
+		// The check only fires once the number of breaches within the evaluation window reaches
+		// the required count.
+		breaches := history.Breaches(name)
+
+		rec.Checks[name] = &state.CheckRecord{
+			Source:             source.Name(),
+			Resource:           check.Resource,
+			Value:              actual,
+			Threshold:          check.ComparisonPercentage,
+			ComparisonOperator: check.ComparisonOperator,
+			Action:             check.Action,
+			Breached:           checkDecision.direction != state.ScaleDirectionNone,
+			Fired:              breaches >= check.GetBreachesRequired(),
+		}
 
-		if checkDecision.direction != state.ScaleDirectionNone {
-			classDecision[checkDecision.direction] = true
+		if breaches < check.GetBreachesRequired() {
+			if breaches > 0 {
+				log.Debug().
+					Str("check-name", name).
+					Int("check-breaches", breaches).
+					Int("check-breaches-required", check.GetBreachesRequired()).
+					Int("check-evaluation-periods", check.GetEvaluationPeriods()).
+					Msg("scaling policy check has not reached required breach count")
+			}
+			continue
+		}
+
+		firedChecks[name] = true
+
+		// When multiple checks fire in the same direction, the largest count is used.
+		dir := actionToDirection(check.Action)
+		count := checkCount(pol, dir, check.GetStepCount(actual, current))
+		if existing, ok := classDecision[dir]; !ok || count > existing {
+			classDecision[dir] = count
+		}
+	}
+
+	history.Prune(activeChecks)
+
+	if err := s.historyBackend.PutCheckHistory(history); err != nil {
+		return nil, err
+	}
+
+	if err := applyCheckExpressions(log, pol, classDecision, firedChecks, unavailableChecks); err != nil {
+		return nil, err
+	}
+
+	// A skipped check may have required the class to keep or add capacity, therefore scaling in
+	// is not performed until the values of all checks are available.
+	if len(unavailableChecks) > 0 {
+		if _, ok := classDecision[state.ScaleDirectionIn]; ok {
+			log.Info().Msg("scaling in not performed as scaling policy check values are unavailable")
+			delete(classDecision, state.ScaleDirectionIn)
 		}
 	}
 	return s.buildSingleDecision(classDecision), nil
 }
 
-func (s *Scale) buildSingleDecision(decisions map[state.ScaleDirection]bool) *decision {
+// checkCount resolves the step count of a fired check, where 0 indicates no step matched, to the
+// policy count of the direction. This allows the counts of multiple checks to be compared, so
+// that a check using the policy count is not outweighed by a smaller step. When scaling out using
+// the bin-pack mode, 0 is retained so that the bin-packing simulation sizes the activity.
+func checkCount(pol *state.ClientScalingPolicy, dir state.ScaleDirection, step int) int {
+	if step > 0 {
+		return step
+	}
+
+	switch dir {
+	case state.ScaleDirectionIn:
+		return pol.ScaleInCount
+	case state.ScaleDirectionOut:
+		if pol.GetScaleOutMode() == state.ScaleOutModeBinPack {
+			return 0
+		}
+		return pol.ScaleOutCount
+	default:
+		return 0
+	}
+}
+
+// scaleInDecision returns the decision to remove the number of nodes required to reach a target
+// count, limited by the policy ScaleInCount so that capacity is removed at the configured rate.
+func scaleInDecision(pol *state.ClientScalingPolicy, count int) *decision {
+	if pol.ScaleInCount > 0 && count > pol.ScaleInCount {
+		count = pol.ScaleInCount
+	}
+	return &decision{direction: state.ScaleDirectionIn, count: count}
+}
+
+// actionToDirection converts the check action to the scaling direction it represents.
+func actionToDirection(action state.ComparisonAction) state.ScaleDirection {
+	switch action {
+	case state.ActionScaleIn:
+		return state.ScaleDirectionIn
+	case state.ActionScaleOut:
+		return state.ScaleDirectionOut
+	default:
+		return state.ScaleDirectionNone
+	}
+}
+
+func (s *Scale) buildSingleDecision(decisions map[state.ScaleDirection]int) *decision {
+	delete(decisions, state.ScaleDirectionNone)
+
 	if len(decisions) == 0 {
 		return nil
 	}
 
 	// ScaleDirectionOut should always trump in.
-	if decisions[state.ScaleDirectionOut] && decisions[state.ScaleDirectionIn] {
-		return &decision{direction: state.ScaleDirectionOut}
-	}
-
-	if decisions[state.ScaleDirectionOut] {
-		return &decision{direction: state.ScaleDirectionOut}
+	if count, ok := decisions[state.ScaleDirectionOut]; ok {
+		return &decision{direction: state.ScaleDirectionOut, count: count}
 	}
-	if decisions[state.ScaleDirectionIn] {
-		return &decision{direction: state.ScaleDirectionIn}
+	if count, ok := decisions[state.ScaleDirectionIn]; ok {
+		return &decision{direction: state.ScaleDirectionIn, count: count}
 	}
 	return nil
 }
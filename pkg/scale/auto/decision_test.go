@@ -88,3 +88,81 @@ func Test_performLessThanCheck(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
 	}
 }
+
+func TestScale_buildSingleDecision(t *testing.T) {
+	testCases := []struct {
+		inputDecisions map[state.ScaleDirection]int
+		expectedOutput *decision
+		name           string
+	}{
+		{
+			inputDecisions: map[state.ScaleDirection]int{state.ScaleDirectionNone: 0},
+			expectedOutput: nil,
+			name:           "no checks fired",
+		},
+		{
+			inputDecisions: map[state.ScaleDirection]int{state.ScaleDirectionIn: 0},
+			expectedOutput: &decision{direction: state.ScaleDirectionIn},
+			name:           "scale in using policy count",
+		},
+		{
+			inputDecisions: map[state.ScaleDirection]int{state.ScaleDirectionIn: 0, state.ScaleDirectionOut: 3},
+			expectedOutput: &decision{direction: state.ScaleDirectionOut, count: 3},
+			name:           "scale out with step count trumps scale in",
+		},
+	}
+
+	s := &Scale{}
+
+	for _, tc := range testCases {
+		actualOutput := s.buildSingleDecision(tc.inputDecisions)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_checkCount(t *testing.T) {
+	fixed := &state.ClientScalingPolicy{ScaleInCount: 2, ScaleOutCount: 5}
+	binPack := &state.ClientScalingPolicy{ScaleInCount: 2, ScaleOutCount: 5, ScaleOutMode: state.ScaleOutModeBinPack}
+
+	testCases := []struct {
+		inputPolicy    *state.ClientScalingPolicy
+		inputDirection state.ScaleDirection
+		inputStep      int
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputPolicy:    fixed,
+			inputDirection: state.ScaleDirectionOut,
+			inputStep:      1,
+			expectedOutput: 1,
+			name:           "step count used",
+		},
+		{
+			inputPolicy:    fixed,
+			inputDirection: state.ScaleDirectionOut,
+			inputStep:      0,
+			expectedOutput: 5,
+			name:           "scale out resolved to policy count",
+		},
+		{
+			inputPolicy:    fixed,
+			inputDirection: state.ScaleDirectionIn,
+			inputStep:      0,
+			expectedOutput: 2,
+			name:           "scale in resolved to policy count",
+		},
+		{
+			inputPolicy:    binPack,
+			inputDirection: state.ScaleDirectionOut,
+			inputStep:      0,
+			expectedOutput: 0,
+			name:           "bin-pack scale out left to simulation",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := checkCount(tc.inputPolicy, tc.inputDirection, tc.inputStep)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -0,0 +1,49 @@
+package auto
+
+import (
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// applyCheckExpressions evaluates the policy expressions against the fired checks, updating the
+// class decision so that a direction with an expression is only present when the expression is
+// true. The step count of the direction is kept, with 0 used if no counting check fired. An
+// expression referencing a check whose value is unavailable is treated as false, as its result
+// cannot be known.
+func applyCheckExpressions(log zerolog.Logger, pol *state.ClientScalingPolicy, classDecision map[state.ScaleDirection]int, fired, unavailable map[string]bool) error {
+	for action, expression := range pol.Expressions {
+		expr, err := state.ParseCheckExpression(expression)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse expression: "+action.String())
+		}
+
+		dir := actionToDirection(action)
+		result := expr.Evaluate(fired) && !referencesAny(expr, unavailable)
+
+		log.Debug().
+			Str("expression-action", action.String()).
+			Str("expression", expression).
+			Bool("expression-result", result).
+			Msg("performed scaling policy expression analysis")
+
+		if !result {
+			delete(classDecision, dir)
+			continue
+		}
+		if _, ok := classDecision[dir]; !ok {
+			classDecision[dir] = 0
+		}
+	}
+	return nil
+}
+
+// referencesAny returns whether the expression references any of the checks.
+func referencesAny(expr state.CheckExpression, checks map[string]bool) bool {
+	for _, name := range expr.Checks() {
+		if checks[name] {
+			return true
+		}
+	}
+	return false
+}
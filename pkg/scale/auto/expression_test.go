@@ -0,0 +1,55 @@
+package auto
+
+import (
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyCheckExpressions(t *testing.T) {
+	pol := &state.ClientScalingPolicy{
+		Expressions: map[state.ComparisonAction]string{state.ActionScaleIn: "cpu-in AND memory-in"},
+	}
+
+	testCases := []struct {
+		inputDecision    map[state.ScaleDirection]int
+		inputFired       map[string]bool
+		inputUnavailable map[string]bool
+		expectedDecision map[state.ScaleDirection]int
+		name             string
+	}{
+		{
+			inputDecision:    map[state.ScaleDirection]int{state.ScaleDirectionIn: 0},
+			inputFired:       map[string]bool{"cpu-in": true},
+			expectedDecision: map[state.ScaleDirection]int{},
+			name:             "scale in removed when expression false",
+		},
+		{
+			inputDecision:    map[state.ScaleDirection]int{state.ScaleDirectionIn: 0},
+			inputFired:       map[string]bool{"cpu-in": true, "memory-in": true},
+			expectedDecision: map[state.ScaleDirection]int{state.ScaleDirectionIn: 0},
+			name:             "scale in kept when expression true",
+		},
+		{
+			inputDecision:    map[state.ScaleDirection]int{state.ScaleDirectionOut: 2},
+			inputFired:       map[string]bool{"cpu-out": true},
+			expectedDecision: map[state.ScaleDirection]int{state.ScaleDirectionOut: 2},
+			name:             "direction without expression unaffected",
+		},
+		{
+			inputDecision:    map[state.ScaleDirection]int{state.ScaleDirectionIn: 0},
+			inputFired:       map[string]bool{"cpu-in": true, "memory-in": true},
+			inputUnavailable: map[string]bool{"memory-in": true},
+			expectedDecision: map[state.ScaleDirection]int{},
+			name:             "scale in removed when referenced check unavailable",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := applyCheckExpressions(zerolog.Nop(), pol, tc.inputDecision, tc.inputFired, tc.inputUnavailable)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedDecision, tc.inputDecision, tc.name)
+	}
+}
@@ -0,0 +1,35 @@
+package auto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// activePause returns the autoscaler pause if one is currently in effect, otherwise nil.
+func (s *Scale) activePause() (*state.AutoscalerPause, error) {
+	pause, err := s.pauseBackend.GetPause()
+	if err != nil {
+		return nil, err
+	}
+
+	if !pause.IsActive(helper.GenerateEventTimestamp()) {
+		return nil, nil
+	}
+	return pause, nil
+}
+
+// pauseDetail returns the human readable explanation of the pause used within evaluation records.
+func pauseDetail(pause *state.AutoscalerPause) string {
+	detail := "autoscaler is paused"
+
+	if pause.Expiry > 0 {
+		detail += fmt.Sprintf(" until %s", time.Unix(0, pause.Expiry).UTC().Format(time.RFC3339))
+	}
+	if pause.Reason != "" {
+		detail += ": " + pause.Reason
+	}
+	return detail
+}
@@ -0,0 +1,53 @@
+package auto
+
+import (
+	"fmt"
+
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// Plan performs a dry-run evaluation of the passed scaling policy against the current resource
+// state of its class. The evaluation runs the policy checks and scaling preconditions in the same
+// manner as the autoscaler, but does not invoke any provider, update the check history or store
+// the evaluation record. The returned record details the decision which would have been made.
+func (s *Scale) Plan(pol *state.ClientScalingPolicy) *state.EvaluationRecord {
+	plan := Scale{
+		logger:          s.logger,
+		nomad:           s.nomad,
+		policyBackend:   s.policyBackend,
+		scaleBackend:    s.scaleBackend,
+		historyBackend:  dryRunHistoryBackend{s.historyBackend},
+		pauseBackend:    s.pauseBackend,
+		scaler:          s.scaler,
+		resourceHandler: s.resourceHandler,
+		metricSources:   s.metricSources,
+		dryRun:          true,
+	}
+
+	logger := helper.LoggerWithNodeClassContext(s.logger, pol.Class)
+	logger.Debug().Msg("performing dry-run scaling policy evaluation")
+
+	rec := plan.newEvaluationRecord(pol.Class)
+
+	if req := plan.evaluate(logger, pol, rec); req != nil {
+		rec.SetOutcome(state.EvaluationOutcomeScaling, "",
+			fmt.Sprintf("would trigger scale %s activity", req.Direction))
+	}
+	return rec
+}
+
+// dryRunHistoryBackend wraps a state.CheckHistoryBackend, allowing the stored history to be read
+// while discarding all writes. This allows dry-run evaluations to account for previous breaches
+// without impacting the decisions of the autoscaler.
+type dryRunHistoryBackend struct {
+	state.CheckHistoryBackend
+}
+
+// PutCheckHistory satisfies the PutCheckHistory function on the state.CheckHistoryBackend
+// interface, discarding the write.
+func (dryRunHistoryBackend) PutCheckHistory(_ *state.CheckHistory) error { return nil }
+
+// DeleteCheckHistory satisfies the DeleteCheckHistory function on the state.CheckHistoryBackend
+// interface, discarding the delete.
+func (dryRunHistoryBackend) DeleteCheckHistory(_ string) error { return nil }
@@ -0,0 +1,33 @@
+package auto
+
+import (
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/jrasell/chemtrail/pkg/state/history/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dryRunHistoryBackend(t *testing.T) {
+	stored := state.NewCheckHistory("test")
+	stored.Record("cpu-out", true, 3)
+
+	backend := memory.NewCheckHistoryBackend()
+	assert.Nil(t, backend.PutCheckHistory(stored))
+
+	dryRun := dryRunHistoryBackend{backend}
+
+	// Reads should return the stored history.
+	actual, err := dryRun.GetCheckHistory("test")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, actual.Breaches("cpu-out"))
+
+	// Writes and deletes should not modify the stored history.
+	actual.Record("cpu-out", true, 3)
+	assert.Nil(t, dryRun.PutCheckHistory(actual))
+	assert.Nil(t, dryRun.DeleteCheckHistory("test"))
+
+	actual, err = backend.GetCheckHistory("test")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, actual.Breaches("cpu-out"))
+}
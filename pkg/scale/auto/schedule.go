@@ -0,0 +1,36 @@
+package auto
+
+import (
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// scheduledDecision returns the decision required to enforce the capacity of an active schedule
+// window. If the window has a desired count, the class is scaled towards it, otherwise the class
+// is scaled to within the overridden minimum and maximum counts. Nil is returned if no window is
+// active or the class already meets the scheduled capacity.
+func scheduledDecision(window *state.ScheduleWindow, pol *state.ClientScalingPolicy, desired, current int) *decision {
+	if window == nil {
+		return nil
+	}
+
+	target := desired
+	if target == 0 {
+		switch {
+		case current < pol.MinCount:
+			target = pol.MinCount
+		case current > pol.MaxCount:
+			target = pol.MaxCount
+		default:
+			return nil
+		}
+	}
+
+	switch {
+	case current < target:
+		return &decision{direction: state.ScaleDirectionOut, count: target - current}
+	case current > target:
+		return scaleInDecision(pol, current-target)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package auto
+
+import (
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scheduledDecision(t *testing.T) {
+	pol := &state.ClientScalingPolicy{MinCount: 4, MaxCount: 8, ScaleInCount: 3}
+	window := &state.ScheduleWindow{Name: "business-hours"}
+
+	testCases := []struct {
+		inputWindow    *state.ScheduleWindow
+		inputDesired   int
+		inputCurrent   int
+		expectedOutput *decision
+		name           string
+	}{
+		{
+			inputWindow:    nil,
+			inputCurrent:   1,
+			expectedOutput: nil,
+			name:           "no active window",
+		},
+		{
+			inputWindow:    window,
+			inputCurrent:   6,
+			expectedOutput: nil,
+			name:           "class within scheduled bounds",
+		},
+		{
+			inputWindow:    window,
+			inputCurrent:   2,
+			expectedOutput: &decision{direction: state.ScaleDirectionOut, count: 2},
+			name:           "class below scheduled min count",
+		},
+		{
+			inputWindow:    window,
+			inputCurrent:   10,
+			expectedOutput: &decision{direction: state.ScaleDirectionIn, count: 2},
+			name:           "class above scheduled max count",
+		},
+		{
+			inputWindow:    window,
+			inputDesired:   4,
+			inputCurrent:   9,
+			expectedOutput: &decision{direction: state.ScaleDirectionIn, count: 3},
+			name:           "class above desired count bounded by scale in count",
+		},
+		{
+			inputWindow:    window,
+			inputDesired:   7,
+			inputCurrent:   5,
+			expectedOutput: &decision{direction: state.ScaleDirectionOut, count: 2},
+			name:           "class below desired count",
+		},
+		{
+			inputWindow:    window,
+			inputDesired:   5,
+			inputCurrent:   5,
+			expectedOutput: nil,
+			name:           "class at desired count",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := scheduledDecision(tc.inputWindow, pol, tc.inputDesired, tc.inputCurrent)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
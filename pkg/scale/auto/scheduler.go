@@ -0,0 +1,199 @@
+package auto
+
+import (
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// policyDiscoveryInterval is the time period between the autoscaler listing the stored
+	// policies in order to start the schedulers of newly written policies.
+	policyDiscoveryInterval = 30 * time.Second
+
+	// maxJitter is the maximum percentage of the evaluation interval by which policy evaluations
+	// can be randomly offset.
+	maxJitter = 50
+)
+
+// runPolicyDiscovery lists the stored scaling policies and starts a scheduler for each policy
+// which does not already have one running. The schedulers run until the passed channel is closed.
+func (s *Scale) runPolicyDiscovery(doneChan <-chan struct{}) {
+	policies, err := s.policyBackend.GetPolicies()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("autoscaler unable to get scaling policies")
+		return
+	}
+
+	if len(policies) == 0 {
+		s.logger.Debug().Msg("no scaling policies found in storage backend for autoscaler to schedule")
+		return
+	}
+
+	s.schedulersLock.Lock()
+	defer s.schedulersLock.Unlock()
+
+	for class, policy := range policies {
+		if s.schedulers[class] {
+			continue
+		}
+		s.schedulers[class] = true
+		s.schedulersWG.Add(1)
+
+		// The first evaluation is randomly offset within the policy interval, so that policies
+		// discovered together are not evaluated at the same instant.
+		go s.runPolicyScheduler(class, time.Duration(s.randFloat64()*float64(s.policyInterval(policy))), doneChan)
+	}
+}
+
+// runPolicyScheduler periodically triggers the evaluation of the class scaling policy, using the
+// policy evaluation interval offset by the configured jitter. The policy is read from the backend
+// prior to each evaluation, so that updates are applied. The scheduler exits when the autoscaler is
+// stopped or the policy is deleted.
+func (s *Scale) runPolicyScheduler(class string, delay time.Duration, doneChan <-chan struct{}) {
+	defer s.schedulersWG.Done()
+
+	defer func() {
+		s.schedulersLock.Lock()
+		delete(s.schedulers, class)
+		s.schedulersLock.Unlock()
+	}()
+
+	logger := helper.LoggerWithNodeClassContext(s.logger, class)
+	logger.Debug().Dur("initial-delay", delay).Msg("starting node class policy scheduler")
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-doneChan:
+			logger.Debug().Msg("stopping node class policy scheduler as a result of shutdown request")
+			return
+		case <-t.C:
+		}
+
+		policy, err := s.policyBackend.GetPolicy(class)
+		if err != nil {
+			logger.Error().Err(err).Msg("autoscaler unable to get scaling policy")
+			t.Reset(s.jitteredInterval(s.policyInterval(nil)))
+			continue
+		}
+
+		if policy == nil {
+			logger.Debug().Msg("stopping node class policy scheduler as policy has been deleted")
+			return
+		}
+
+		s.triggerEvaluation(logger, policy)
+		t.Reset(s.jitteredInterval(s.policyInterval(policy)))
+	}
+}
+
+// triggerEvaluation submits the policy to the worker pool for evaluation. If a previous evaluation
+// of the class is still in progress, the evaluation is skipped to avoid putting more pressure on a
+// system which may be under load causing slow API responses.
+func (s *Scale) triggerEvaluation(logger zerolog.Logger, policy *state.ClientScalingPolicy) {
+
+	// Check whether an operator has paused the autoscaler. If the pause state cannot be read, the
+	// evaluation is not performed as the autoscaler may have been paused.
+	pause, err := s.activePause()
+	if err != nil {
+		logger.Error().Err(err).Msg("autoscaler unable to get pause state")
+		return
+	}
+	if pause != nil {
+		rec := s.newEvaluationRecord(policy.Class)
+		s.recordSkippedEvaluation(logger, rec, skipReasonAutoscalerPaused, pauseDetail(pause))
+		s.writeEvaluationRecord(logger, rec)
+		return
+	}
+
+	// Check whether the policy is enabled. Disabled policies are still recorded, so operators can
+	// see why the class is not being evaluated.
+	if !policy.Enabled {
+		rec := s.newEvaluationRecord(policy.Class)
+		s.recordSkippedEvaluation(logger, rec, skipReasonPolicyDisabled, "scaling policy is disabled")
+		s.writeEvaluationRecord(logger, rec)
+		return
+	}
+
+	if !s.startEvaluation(policy.Class) {
+		logger.Info().Msg("previous evaluation of node class in progress, skipping new assessment")
+		return
+	}
+
+	logger.Debug().Msg("triggering new autoscaler evaluation of node class")
+
+	if err := s.pool.Invoke(policy); err != nil {
+		logger.Error().Err(err).Msg("failed to invoke autoscaling worker thread")
+		s.finishEvaluation(policy.Class)
+	}
+}
+
+// startEvaluation marks the class as having an evaluation in progress. If the class already has
+// an evaluation in progress, false is returned.
+func (s *Scale) startEvaluation(class string) bool {
+	s.inProgressLock.Lock()
+	defer s.inProgressLock.Unlock()
+
+	if s.inProgress[class] {
+		return false
+	}
+	s.inProgress[class] = true
+	return true
+}
+
+// finishEvaluation marks the evaluation of the class as no longer in progress.
+func (s *Scale) finishEvaluation(class string) {
+	s.inProgressLock.Lock()
+	delete(s.inProgress, class)
+	s.inProgressLock.Unlock()
+}
+
+// evaluationsInProgress returns the number of class evaluations currently in progress.
+func (s *Scale) evaluationsInProgress() int {
+	s.inProgressLock.Lock()
+	defer s.inProgressLock.Unlock()
+	return len(s.inProgress)
+}
+
+// policyInterval returns the evaluation interval of the policy, falling back to the autoscaler
+// interval when the policy does not specify one. A nil policy returns the autoscaler interval.
+func (s *Scale) policyInterval(policy *state.ClientScalingPolicy) time.Duration {
+	if policy != nil && policy.EvaluationInterval > 0 {
+		return time.Duration(policy.EvaluationInterval) * time.Second
+	}
+	return time.Duration(s.interval) * time.Second
+}
+
+// jitteredInterval returns the interval randomly offset by up to the configured jitter percentage
+// in either direction.
+func (s *Scale) jitteredInterval(interval time.Duration) time.Duration {
+	return jitterInterval(interval, s.jitter, s.randFloat64())
+}
+
+// randFloat64 returns a pseudo-random number in the range [0.0,1.0) from the autoscaler random
+// source, which is not safe for concurrent use on its own.
+func (s *Scale) randFloat64() float64 {
+	s.randLock.Lock()
+	defer s.randLock.Unlock()
+	return s.rand.Float64()
+}
+
+// jitterInterval offsets the interval by up to jitter percent in either direction, using r, a
+// number in the range [0.0,1.0), to choose the offset. Jitter values are limited to between 0 and
+// maxJitter percent, ensuring the offset interval is never less than half the interval.
+func jitterInterval(interval time.Duration, jitter int, r float64) time.Duration {
+	switch {
+	case jitter <= 0:
+		return interval
+	case jitter > maxJitter:
+		jitter = maxJitter
+	}
+
+	maxOffset := float64(interval) * float64(jitter) / 100
+	return interval + time.Duration((r*2-1)*maxOffset)
+}
@@ -0,0 +1,91 @@
+package auto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jitterInterval(t *testing.T) {
+	testCases := []struct {
+		inputInterval  time.Duration
+		inputJitter    int
+		inputRand      float64
+		expectedOutput time.Duration
+		name           string
+	}{
+		{
+			inputInterval:  60 * time.Second,
+			inputJitter:    0,
+			inputRand:      0.9,
+			expectedOutput: 60 * time.Second,
+			name:           "jitter disabled",
+		},
+		{
+			inputInterval:  60 * time.Second,
+			inputJitter:    10,
+			inputRand:      0,
+			expectedOutput: 54 * time.Second,
+			name:           "maximum negative offset",
+		},
+		{
+			inputInterval:  60 * time.Second,
+			inputJitter:    10,
+			inputRand:      0.5,
+			expectedOutput: 60 * time.Second,
+			name:           "no offset",
+		},
+		{
+			inputInterval:  60 * time.Second,
+			inputJitter:    10,
+			inputRand:      0.75,
+			expectedOutput: 63 * time.Second,
+			name:           "positive offset",
+		},
+		{
+			inputInterval:  60 * time.Second,
+			inputJitter:    200,
+			inputRand:      0,
+			expectedOutput: 30 * time.Second,
+			name:           "jitter limited to maximum",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := jitterInterval(tc.inputInterval, tc.inputJitter, tc.inputRand)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func TestScale_policyInterval(t *testing.T) {
+	s := Scale{interval: 180}
+
+	testCases := []struct {
+		inputPolicy    *state.ClientScalingPolicy
+		expectedOutput time.Duration
+		name           string
+	}{
+		{
+			inputPolicy:    nil,
+			expectedOutput: 180 * time.Second,
+			name:           "nil policy uses autoscaler interval",
+		},
+		{
+			inputPolicy:    &state.ClientScalingPolicy{},
+			expectedOutput: 180 * time.Second,
+			name:           "policy without interval uses autoscaler interval",
+		},
+		{
+			inputPolicy:    &state.ClientScalingPolicy{EvaluationInterval: 30},
+			expectedOutput: 30 * time.Second,
+			name:           "policy interval",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := s.policyInterval(tc.inputPolicy)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
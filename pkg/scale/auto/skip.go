@@ -0,0 +1,69 @@
+package auto
+
+import (
+	metrics "github.com/armon/go-metrics"
+	"github.com/gofrs/uuid"
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+)
+
+// skipReason identifies why an autoscaling evaluation of a class did not result in a scaling
+// activity being triggered.
+type skipReason string
+
+// String returns the string form of the skipReason.
+func (sr skipReason) String() string { return string(sr) }
+
+const (
+	// skipReasonCooldown indicates the class is within the cooldown period following a previous
+	// scaling activity.
+	skipReasonCooldown skipReason = "cooldown"
+
+	// skipReasonPrecondition indicates the scaling request failed the scale backend OKToScale
+	// precondition checks.
+	skipReasonPrecondition skipReason = "precondition-failed"
+
+	// skipReasonPolicyDisabled indicates the scaling policy of the class is disabled.
+	skipReasonPolicyDisabled skipReason = "policy-disabled"
+
+	// skipReasonAutoscalerPaused indicates the autoscaler has been paused by an operator.
+	skipReasonAutoscalerPaused skipReason = "autoscaler-paused"
+)
+
+// recordSkippedEvaluation logs the skipped evaluation along with the reason and emits a telemetry
+// counter labelled with the class and reason so operators can track how often evaluations are not
+// acted upon. The reason is also set as the outcome of the evaluation record.
+func (s *Scale) recordSkippedEvaluation(log zerolog.Logger, rec *state.EvaluationRecord, reason skipReason, detail string) {
+	log.Info().
+		Str("skip-reason", reason.String()).
+		Str("skip-detail", detail).
+		Msg("autoscaling evaluation skipped")
+
+	rec.SetOutcome(state.EvaluationOutcomeSkipped, reason.String(), detail)
+
+	// Dry-run evaluations do not represent real autoscaler activity and are therefore not
+	// included within the telemetry.
+	if s.dryRun {
+		return
+	}
+	metrics.IncrCounterWithLabels([]string{"autoscaler", "evaluation", "skipped"}, 1,
+		[]metrics.Label{{Name: "class", Value: rec.Class}, {Name: "reason", Value: reason.String()}})
+}
+
+// newEvaluationRecord creates the record of a new evaluation of the class.
+func (s *Scale) newEvaluationRecord(class string) *state.EvaluationRecord {
+	id, err := uuid.NewV4()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to generate evaluation ID")
+	}
+	return state.NewEvaluationRecord(id, class, helper.GenerateEventTimestamp())
+}
+
+// writeEvaluationRecord stores the evaluation record within the state backend. Failures are only
+// logged, as the record is informational and should not impact scaling.
+func (s *Scale) writeEvaluationRecord(log zerolog.Logger, rec *state.EvaluationRecord) {
+	if err := s.scaleBackend.WriteEvaluation(rec); err != nil {
+		log.Error().Err(err).Msg("failed to write autoscaling evaluation record")
+	}
+}
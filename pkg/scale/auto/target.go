@@ -0,0 +1,141 @@
+package auto
+
+import (
+	"math"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+)
+
+// performTargetTracking is the target tracking decision strategy. Each enabled target calculates
+// the class size required to bring the resource utilisation to the target value. The largest size
+// is used so that the most constrained resource is satisfied, bounded by the policy minimum and
+// maximum counts.
+func (s *Scale) performTargetTracking(log zerolog.Logger, pol *state.ClientScalingPolicy, rec *state.EvaluationRecord) (*decision, error) {
+
+	// Gather the current allocated resource stats for the node class.
+	allocStats, err := s.resourceHandler.GetClassResourceAllocation(pol.Class)
+	if err != nil {
+		return nil, err
+	}
+	current := len(s.resourceHandler.GetNodesOfClass(pol.Class))
+
+	var (
+		desired     int
+		tracked     bool
+		unavailable bool
+	)
+
+	for name, target := range pol.Targets {
+		if !target.Enabled {
+			log.Debug().
+				Str("target-name", name).
+				Msg("scaling policy target administratively disabled")
+			continue
+		}
+
+		// A target whose usage is unavailable is skipped, rather than preventing the other
+		// targets from being tracked.
+		if target.Resource.IsUsage() && !allocStats.UsageCollected {
+			log.Warn().
+				Str("target-name", name).
+				Msg("resource usage has not been collected, skipping scaling policy target")
+			unavailable = true
+			continue
+		}
+
+		var actual float64
+
+		switch target.Resource {
+		case state.ScaleResourceCPU:
+			actual = allocStats.CPU
+		case state.ScaleResourceMemory:
+			actual = allocStats.Memory
+		case state.ScaleResourceCPUUsed:
+			actual = allocStats.CPUUsed
+		case state.ScaleResourceMemoryUsed:
+			actual = allocStats.MemoryUsed
+		}
+
+		count := targetDesiredCount(target, actual, current)
+
+		rec.Checks[name] = &state.CheckRecord{
+			Source:       state.MetricSourceNomad.String(),
+			Resource:     target.Resource,
+			Value:        actual,
+			Threshold:    target.TargetValue,
+			Breached:     count != current,
+			Fired:        count != current,
+			DesiredCount: count,
+		}
+		log.Debug().
+			Str("target-name", name).
+			Str("target-resource", target.Resource.String()).
+			Float64("target-value", target.TargetValue).
+			Float64("target-resource-actual", actual).
+			Int("target-desired-count", count).
+			Msg("performing scaling policy target analysis")
+
+		if !tracked || count > desired {
+			desired = count
+		}
+		tracked = true
+	}
+
+	if !tracked {
+		return nil, nil
+	}
+
+	// A skipped target may require a larger class, therefore scaling in is not performed until
+	// the usage of all targets is available.
+	d := targetDecision(pol, desired, current)
+	if unavailable && d != nil && d.direction == state.ScaleDirectionIn {
+		log.Info().Msg("scaling in not performed as scaling policy target usage is unavailable")
+		return nil, nil
+	}
+	return d, nil
+}
+
+// targetDesiredCount calculates the number of nodes required for the actual resource utilisation
+// to reach the target value. The current count is returned if the utilisation is within the
+// target tolerance, and the change is limited by the target MaxChange.
+func targetDesiredCount(target *state.PolicyTarget, actual float64, current int) int {
+	if current < 1 || math.Abs(actual-target.TargetValue) <= target.GetTolerance() {
+		return current
+	}
+
+	desired := int(math.Ceil(float64(current) * actual / target.TargetValue))
+	if desired < 1 {
+		desired = 1
+	}
+
+	if target.MaxChange > 0 {
+		switch {
+		case desired > current+target.MaxChange:
+			desired = current + target.MaxChange
+		case desired < current-target.MaxChange:
+			desired = current - target.MaxChange
+		}
+	}
+	return desired
+}
+
+// targetDecision converts the desired count into a scaling decision, bounding the count by the
+// policy minimum and maximum. Nil is returned if no change is required.
+func targetDecision(pol *state.ClientScalingPolicy, desired, current int) *decision {
+	if desired < pol.MinCount {
+		desired = pol.MinCount
+	}
+	if desired > pol.MaxCount {
+		desired = pol.MaxCount
+	}
+
+	switch {
+	case desired > current:
+		return &decision{direction: state.ScaleDirectionOut, count: desired - current}
+	case desired < current:
+		return scaleInDecision(pol, current-desired)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package auto
+
+import (
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_targetDesiredCount(t *testing.T) {
+	testCases := []struct {
+		inputTarget    *state.PolicyTarget
+		inputActual    float64
+		inputCurrent   int
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputTarget:    &state.PolicyTarget{TargetValue: 65},
+			inputActual:    68,
+			inputCurrent:   4,
+			expectedOutput: 4,
+			name:           "within default tolerance",
+		},
+		{
+			inputTarget:    &state.PolicyTarget{TargetValue: 65},
+			inputActual:    90,
+			inputCurrent:   4,
+			expectedOutput: 6,
+			name:           "above target rounds up",
+		},
+		{
+			inputTarget:    &state.PolicyTarget{TargetValue: 65, MaxChange: 1},
+			inputActual:    90,
+			inputCurrent:   4,
+			expectedOutput: 5,
+			name:           "above target limited by max change",
+		},
+		{
+			inputTarget:    &state.PolicyTarget{TargetValue: 65, Tolerance: 2},
+			inputActual:    30,
+			inputCurrent:   6,
+			expectedOutput: 3,
+			name:           "below target with custom tolerance",
+		},
+		{
+			inputTarget:    &state.PolicyTarget{TargetValue: 65},
+			inputActual:    90,
+			inputCurrent:   0,
+			expectedOutput: 0,
+			name:           "empty class",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := targetDesiredCount(tc.inputTarget, tc.inputActual, tc.inputCurrent)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_targetDecision(t *testing.T) {
+	pol := &state.ClientScalingPolicy{MinCount: 2, MaxCount: 6, ScaleInCount: 3}
+
+	testCases := []struct {
+		inputDesired   int
+		inputCurrent   int
+		expectedOutput *decision
+		name           string
+	}{
+		{
+			inputDesired:   4,
+			inputCurrent:   4,
+			expectedOutput: nil,
+			name:           "no change required",
+		},
+		{
+			inputDesired:   9,
+			inputCurrent:   4,
+			expectedOutput: &decision{direction: state.ScaleDirectionOut, count: 2},
+			name:           "scale out bounded by max count",
+		},
+		{
+			inputDesired:   3,
+			inputCurrent:   5,
+			expectedOutput: &decision{direction: state.ScaleDirectionIn, count: 2},
+			name:           "scale in",
+		},
+		{
+			inputDesired:   1,
+			inputCurrent:   6,
+			expectedOutput: &decision{direction: state.ScaleDirectionIn, count: 3},
+			name:           "scale in bounded by scale in count",
+		},
+		{
+			inputDesired:   1,
+			inputCurrent:   2,
+			expectedOutput: nil,
+			name:           "scale in bounded by min count",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := targetDecision(pol, tc.inputDesired, tc.inputCurrent)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
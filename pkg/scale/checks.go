@@ -1,24 +1,107 @@
 package scale
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
 )
 
-func (b *Backend) checkNewCount(policy *state.ClientScalingPolicy, dir state.ScaleDirection) (int, error) {
-	nodes := b.resourceHandler.GetNodesOfClass(policy.Class)
+func (b *Backend) checkNewCount(req *state.ScalingRequest) (int, error) {
+	nodes := b.resourceHandler.GetNodesOfClass(req.Policy.Class)
 
-	switch dir {
+	switch req.Direction {
 	case state.ScaleDirectionIn:
-		if (len(nodes) - policy.ScaleInCount) < policy.MinCount {
+		if (len(nodes) - req.GetCount()) < req.Policy.MinCount {
 			return http.StatusPreconditionFailed, errScalingInCountCheckFailed
 		}
+
+		// Protected nodes cannot be removed, therefore enough unprotected nodes must be available
+		// to satisfy the requested count.
+		if b.resourceHandler.GetRemovableNodeCount(req.Policy.Class, req.Policy.ScaleInProtection) < req.GetCount() {
+			return http.StatusPreconditionFailed, errScalingInProtectedCheckFailed
+		}
 	case state.ScaleDirectionOut:
-		if (len(nodes) + policy.ScaleOutCount) > policy.MaxCount {
+		if (len(nodes) + req.GetCount()) > req.Policy.MaxCount {
 			return http.StatusPreconditionFailed, errScalingOutCountCheckFailed
 		}
 	}
 
 	return http.StatusOK, nil
 }
+
+// calculateBinPackCount runs the bin-packing simulation for the class and sets the request count
+// to the number of nodes required, capped by the policy MaxCount. If the simulation finds no new
+// nodes are required, the count is left unset so the policy ScaleOutCount is used.
+func (b *Backend) calculateBinPackCount(logger zerolog.Logger, req *state.ScalingRequest) error {
+	sim, err := b.resourceHandler.SimulateClassScaleOut(req.Policy.Class)
+	if err != nil {
+		return err
+	}
+	req.Simulation = sim
+
+	logger.Info().
+		Int("queued-allocs", sim.QueuedAllocs).
+		Int("placed-existing", sim.PlacedExisting).
+		Int("placed-new", sim.PlacedNew).
+		Int("unplaceable", sim.Unplaceable).
+		Int("nodes-required", sim.NodesRequired).
+		Msg("performed scale out bin-packing simulation")
+
+	if sim.NodesRequired < 1 {
+		return nil
+	}
+
+	count := sim.NodesRequired
+	if headroom := req.Policy.MaxCount - len(b.resourceHandler.GetNodesOfClass(req.Policy.Class)); count > headroom {
+		count = headroom
+	}
+	if count < 1 {
+		return errScalingOutCountCheckFailed
+	}
+	req.Count = count
+	return nil
+}
+
+// checkScaleInPlacement discovers the nodes to target when scaling in and checks that the
+// allocations running on the nodes can be placed onto the remaining nodes of the class. The
+// targets are stored on the request so that the checked nodes are those removed.
+func (b *Backend) checkScaleInPlacement(req *state.ScalingRequest) (int, error) {
+	if len(req.TargetNodeIDs) == 0 {
+		targets, err := b.selectScaleInTargets(req)
+		if err != nil {
+			return http.StatusUnprocessableEntity, err
+		}
+		req.TargetNodeIDs = targets
+	}
+
+	unplaceable, err := b.resourceHandler.CheckNodeRemoval(req.Policy.Class, req.TargetNodeIDs)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if unplaceable > 0 {
+		return http.StatusPreconditionFailed,
+			fmt.Errorf("%v: %v allocations on nodes %s", errScalingInPlacementFailed, unplaceable,
+				strings.Join(req.TargetNodeIDs, ", "))
+	}
+	return http.StatusOK, nil
+}
+
+// selectScaleInTargets selects the nodes to remove when scaling in, using the policy selector and
+// protection. An error is returned if fewer nodes than the request count can be selected.
+func (b *Backend) selectScaleInTargets(req *state.ScalingRequest) ([]string, error) {
+	nodes := b.resourceHandler.SelectScaleInNodes(req.Policy.Class, req.Policy.ScaleInSelector,
+		req.Policy.ScaleInProtection, req.GetCount())
+
+	if len(nodes) < req.GetCount() || len(nodes) == 0 {
+		return nil, errScalingInTargetNotFound
+	}
+
+	targets := make([]string, len(nodes))
+	for i, node := range nodes {
+		targets[i] = node.ID
+	}
+	return targets, nil
+}
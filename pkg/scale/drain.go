@@ -2,7 +2,9 @@ package scale
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -12,32 +14,232 @@ import (
 )
 
 const (
-	drainDeadlineMinutes = 5
+	// drainProgressInterval is the time period between drain progress events being written to the
+	// scaling activity.
+	drainProgressInterval = 30 * time.Second
 )
 
-func (b *Backend) removeNodeFromCluster(nodeID string, scaleID uuid.UUID) error {
+// drainNodes drains the nodes from the Nomad cluster in batches of the configured size, with the
+// nodes of each batch drained in parallel. If any node of a batch fails to drain, the remaining
+// batches are not started so that no further capacity is removed. The same applies if the context
+// is cancelled. The IDs of the nodes which drained successfully are returned, along with an error
+// detailing those which failed.
+func (b *Backend) drainNodes(ctx context.Context, nodeIDs []string, scaleID uuid.UUID, drain *state.DrainConfig) ([]string, error) {
+	batchSize := drain.GetBatchSize(len(nodeIDs))
+
+	var drained []string
+
+	for start := 0; start < len(nodeIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(nodeIDs) {
+			end = len(nodeIDs)
+		}
+		batch := nodeIDs[start:end]
+
+		errs := make([]error, len(batch))
+
+		var wg sync.WaitGroup
+
+		for i := range batch {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = b.removeNodeFromCluster(ctx, batch[i], scaleID, drain)
+			}(i)
+		}
+		wg.Wait()
+
+		var failed []string
+
+		for i, nodeID := range batch {
+			if errs[i] != nil {
+				failed = append(failed, nodeID)
+				b.sendNodeEvent(scaleID, nodeID, state.ScaleStatusFailed,
+					fmt.Sprintf("failed to drain node %s: %v", nodeID, errs[i]))
+				continue
+			}
+			drained = append(drained, nodeID)
+		}
+
+		if len(failed) > 0 {
+			for _, nodeID := range nodeIDs[end:] {
+				b.sendNodeEvent(scaleID, nodeID, state.ScaleStatusFailed,
+					fmt.Sprintf("node %s not drained due to an earlier drain failure", nodeID))
+			}
+			return drained, fmt.Errorf("%v: %s", errDrainFailed, strings.Join(failed, ", "))
+		}
+
+		if ctx.Err() != nil {
+			for _, nodeID := range nodeIDs[end:] {
+				b.sendNodeEvent(scaleID, nodeID, state.ScaleStatusFailed,
+					fmt.Sprintf("node %s not drained as the activity was aborted", nodeID))
+			}
+			return drained, fmt.Errorf("%v: %v", errScalingActivityAborted, ctx.Err())
+		}
+	}
+	return drained, nil
+}
+
+func (b *Backend) removeNodeFromCluster(ctx context.Context, nodeID string, scaleID uuid.UUID, drain *state.DrainConfig) error {
 	b.logger.Info().
 		Str("node-id", nodeID).
+		Dur("drain-deadline", drain.GetDeadline()).
+		Dur("drain-timeout", drain.GetTimeout()).
+		Bool("drain-ignore-system-jobs", drain.GetIgnoreSystemJobs()).
 		Msg("removing node from Nomad cluster")
 
-	drainSpec := api.DrainSpec{Deadline: drainDeadlineMinutes * time.Minute}
+	drainSpec := api.DrainSpec{
+		Deadline:         drain.GetDeadline(),
+		IgnoreSystemJobs: drain.GetIgnoreSystemJobs(),
+	}
 
 	resp, err := b.nomad.Client.Nodes().UpdateDrain(nodeID, &drainSpec, false, nil)
 	if err != nil {
 		return err
 	}
-	b.monitorNodeDrain(nodeID, scaleID, resp.LastIndex)
+	b.sendNodeEvent(scaleID, nodeID, state.ScaleStatusInProgress, fmt.Sprintf("started drain of node %s", nodeID))
 
-	return nil
+	return b.monitorNodeDrain(ctx, nodeID, scaleID, resp.LastIndex, drain)
 }
 
-func (b *Backend) monitorNodeDrain(nodeID string, scaleID uuid.UUID, index uint64) {
-	for msg := range b.nomad.Client.Nodes().MonitorDrain(context.Background(), nodeID, index, false) {
-		b.eventChan <- &state.EventMessage{
-			ID:        scaleID,
-			Timestamp: helper.GenerateEventTimestamp(),
-			Source:    eventSourceNomad,
-			Message:   strings.ToLower(msg.Message),
+// monitorNodeDrain writes the Nomad drain messages, along with periodic progress updates, to the
+// scaling activity until the drain completes. If the drain does not complete within the configured
+// timeout, the monitor reports an error, or the context is cancelled, the drain is cancelled and the
+// node returned to service, as the activity will not go on to terminate it. An error is returned
+// in each of these cases.
+func (b *Backend) monitorNodeDrain(parent context.Context, nodeID string, scaleID uuid.UUID, index uint64, drain *state.DrainConfig) error {
+	ctx := parent
+	if timeout := drain.GetTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	ticker := time.NewTicker(drainProgressInterval)
+	defer ticker.Stop()
+
+	msgChan := b.nomad.Client.Nodes().MonitorDrain(ctx, nodeID, index, drain.GetIgnoreSystemJobs())
+
+	// The monitor closes the channel following an error message, such as when the Nomad API
+	// cannot be queried. This must not be mistaken for a completed drain.
+	var monitorErr string
+
+	for {
+		select {
+		case msg, ok := <-msgChan:
+			if !ok {
+				if parent.Err() != nil {
+					b.cancelNodeDrain(scaleID, nodeID, "activity aborted")
+					return fmt.Errorf("%v: %v", errScalingActivityAborted, parent.Err())
+				}
+				if ctx.Err() == context.DeadlineExceeded {
+					b.cancelNodeDrain(scaleID, nodeID, "drain timed out")
+					return fmt.Errorf("%v: node %s did not drain within %s", errDrainTimeout, nodeID, drain.GetTimeout())
+				}
+				if monitorErr != "" {
+					b.cancelNodeDrain(scaleID, nodeID, "drain monitor failed")
+					return fmt.Errorf("%v: node %s: %s", errDrainMonitorFailed, nodeID, monitorErr)
+				}
+				return nil
+			}
+			if msg.Level == api.MonitorMsgLevelError {
+				monitorErr = msg.Message
+			}
+			b.sendDrainEvent(scaleID, nodeID, strings.ToLower(msg.Message))
+
+		case <-ticker.C:
+			allocs, err := b.countDrainingAllocs(nodeID, drain.GetIgnoreSystemJobs())
+			if err != nil {
+				b.logger.Error().Err(err).Str("node-id", nodeID).Msg("failed to list allocations of draining node")
+				continue
+			}
+
+			// Multiple nodes can be drained in parallel, therefore the node is identified within
+			// the progress message.
+			b.sendDrainEvent(scaleID, nodeID,
+				fmt.Sprintf("node %s %s", nodeID, drainProgressMessage(allocs, time.Since(start), drain)))
 		}
 	}
 }
+
+// cancelNodeDrain cancels the drain of the node and marks it eligible for scheduling, returning it
+// to service. The node is recorded as failed within the scaling activity, including the reason.
+func (b *Backend) cancelNodeDrain(scaleID uuid.UUID, nodeID, reason string) {
+	if _, err := b.nomad.Client.Nodes().UpdateDrain(nodeID, nil, true, nil); err != nil {
+		b.logger.Error().Err(err).Str("node-id", nodeID).Msg("failed to cancel node drain")
+		b.sendNodeEvent(scaleID, nodeID, state.ScaleStatusFailed,
+			fmt.Sprintf("failed to cancel drain of node %s: %v", nodeID, err))
+		return
+	}
+	b.sendNodeEvent(scaleID, nodeID, state.ScaleStatusFailed,
+		fmt.Sprintf("cancelled drain of node %s and marked eligible: %s", nodeID, reason))
+}
+
+// countDrainingAllocs counts the allocations on the node which are yet to be drained.
+func (b *Backend) countDrainingAllocs(nodeID string, ignoreSystemJobs bool) (int, error) {
+	allocs, _, err := b.nomad.Client.Nodes().Allocations(nodeID, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, alloc := range allocs {
+		if alloc.ClientStatus != "pending" && alloc.ClientStatus != "running" {
+			continue
+		}
+		if ignoreSystemJobs && alloc.Job != nil && alloc.Job.Type != nil && *alloc.Job.Type == "system" {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (b *Backend) sendDrainEvent(scaleID uuid.UUID, nodeID, message string) {
+	b.eventChan <- &state.EventMessage{
+		ID:         scaleID,
+		Timestamp:  helper.GenerateEventTimestamp(),
+		Source:     eventSourceNomad,
+		Message:    message,
+		NodeID:     nodeID,
+		NodeStatus: state.ScaleStatusInProgress,
+	}
+}
+
+// sendNodeEvent sends an event detailing an individual node being added or removed, so that the
+// outcome of each node is recorded within the scaling activity.
+func (b *Backend) sendNodeEvent(scaleID uuid.UUID, nodeID string, status state.ScaleStatus, message string) {
+	b.eventChan <- &state.EventMessage{
+		ID:         scaleID,
+		Timestamp:  helper.GenerateEventTimestamp(),
+		Source:     eventSourceNode,
+		Message:    message,
+		NodeID:     nodeID,
+		NodeStatus: status,
+	}
+}
+
+// drainProgressMessage builds the drain progress event message, detailing the allocations left
+// to drain and the time remaining until the drain deadline and timeout.
+func drainProgressMessage(allocs int, elapsed time.Duration, drain *state.DrainConfig) string {
+	msg := fmt.Sprintf("drain in progress with %v allocations remaining", allocs)
+
+	if deadline := drain.GetDeadline(); deadline > 0 {
+		msg += fmt.Sprintf(", %s until deadline", remaining(deadline, elapsed))
+	}
+	if timeout := drain.GetTimeout(); timeout > 0 {
+		msg += fmt.Sprintf(", %s until timeout", remaining(timeout, elapsed))
+	}
+	return msg
+}
+
+// remaining returns the time remaining of the duration after the elapsed time, rounded to the
+// second. Once the duration has passed, 0 is returned.
+func remaining(d, elapsed time.Duration) time.Duration {
+	if elapsed >= d {
+		return 0
+	}
+	return (d - elapsed).Round(time.Second)
+}
@@ -0,0 +1,52 @@
+package scale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_drainProgressMessage(t *testing.T) {
+	testCases := []struct {
+		inputAllocs    int
+		inputElapsed   time.Duration
+		inputDrain     *state.DrainConfig
+		expectedOutput string
+		name           string
+	}{
+		{
+			inputAllocs:    3,
+			inputElapsed:   90 * time.Second,
+			inputDrain:     nil,
+			expectedOutput: "drain in progress with 3 allocations remaining, 3m30s until deadline",
+			name:           "default drain config",
+		},
+		{
+			inputAllocs:    12,
+			inputElapsed:   30 * time.Minute,
+			inputDrain:     &state.DrainConfig{Deadline: 7200, Timeout: 9000},
+			expectedOutput: "drain in progress with 12 allocations remaining, 1h30m0s until deadline, 2h0m0s until timeout",
+			name:           "deadline and timeout",
+		},
+		{
+			inputAllocs:    1,
+			inputElapsed:   10 * time.Minute,
+			inputDrain:     &state.DrainConfig{Deadline: 300},
+			expectedOutput: "drain in progress with 1 allocations remaining, 0s until deadline",
+			name:           "deadline passed",
+		},
+		{
+			inputAllocs:    2,
+			inputElapsed:   10 * time.Second,
+			inputDrain:     &state.DrainConfig{Force: true},
+			expectedOutput: "drain in progress with 2 allocations remaining",
+			name:           "forced drain without timeout",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, drainProgressMessage(tc.inputAllocs, tc.inputElapsed, tc.inputDrain), tc.name)
+	}
+}
@@ -12,4 +12,14 @@ var (
 	errNoNodesFoundInClass        = errors.New("no Nomad nodes found of client class")
 	errScalingInCountCheckFailed  = errors.New("scaling in activity would break policy minimum threshold")
 	errScalingOutCountCheckFailed = errors.New("scaling out activity would break policy maximum threshold")
+	errScalingInTargetNotFound    = errors.New("failed to discover nodes in class to scale in")
+	errScalingInPlacementFailed   = errors.New("scaling in activity would leave allocations unable to be placed on remaining nodes")
+
+	errDrainTimeout                  = errors.New("node drain timed out")
+	errDrainFailed                   = errors.New("failed to drain nodes")
+	errDrainMonitorFailed            = errors.New("failed to monitor node drain")
+	errScaleOutJoinTimeout           = errors.New("new nodes did not join class before timeout")
+	errScalingInProtectedCheckFailed = errors.New("scaling in activity requires more nodes than are unprotected within the class")
+	errScalingActivityAborted        = errors.New("scaling activity aborted")
+	errScalingActivityInProgress     = errors.New("scaling activity already in progress for class")
 )
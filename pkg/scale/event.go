@@ -13,6 +13,14 @@ const (
 	// is typically used to denote the start of end of a scaling activity.
 	eventSourceChemtrail = "chemtrail"
 
+	// eventSourceNode is the source to use when events detail the outcome of an individual node
+	// being added or removed during a scaling activity.
+	eventSourceNode = "node"
+
+	// eventSourceSimulation is the source to use when events detail the result of a scale out
+	// bin-packing simulation.
+	eventSourceSimulation = "simulation"
+
 	// eventMessageSuccess is the message used when a scaling activity has finished and has been
 	// successful.
 	eventMessageSuccess = "scaling activity has successfully completed"
@@ -87,6 +95,8 @@ func (b *Backend) handleBackendUpdate(msg *state.EventMessage) error {
 			Message:   msg.Message,
 			Source:    msg.Source,
 		},
+		NodeID:     msg.NodeID,
+		NodeStatus: msg.NodeStatus,
 	}
 	return b.scaleState.WriteRequestEvent(&stateUpdate)
 }
@@ -0,0 +1,79 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// scaleOut triggers the provider scale out and then waits for the new nodes to join the class as
+// ready and eligible, so that the activity only completes once the capacity is usable.
+func (b *Backend) scaleOut(ctx context.Context, req *state.ScalingRequest) error {
+	prov := b.clientProvider[req.Policy.Provider]
+
+	// If we are using the NoOp provider, no nodes will join the cluster.
+	if req.Policy.Provider == state.NoOpClientProvider {
+		return prov.ScaleOut(req)
+	}
+
+	// Nodes registered after the current Nomad node index are those launched by this activity.
+	// The watch is started before the provider is called, so that no joins are missed.
+	_, meta, err := b.nomad.Client.Nodes().List(nil)
+	if err != nil {
+		return err
+	}
+
+	joins, stop := b.resourceHandler.WatchNodeJoins(req.Policy.Class, meta.LastIndex)
+	defer stop()
+
+	start := time.Now()
+
+	if err := prov.ScaleOut(req); err != nil {
+		return err
+	}
+	return b.awaitNodeJoins(ctx, req, joins, start, req.Policy.GetScaleOutJoinTimeout())
+}
+
+// awaitNodeJoins waits for the number of nodes requested to join the class, writing an event to
+// the scaling activity as each node joins and recording the time taken for the node to become
+// ready. If the nodes do not join within the timeout, or the context is cancelled, an error is
+// returned.
+func (b *Backend) awaitNodeJoins(ctx context.Context, req *state.ScalingRequest, joins <-chan string, start time.Time, timeout time.Duration) error {
+	expected := req.GetCount()
+	joined := make(map[string]bool, expected)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for len(joined) < expected {
+		select {
+		case nodeID := <-joins:
+
+			// A node which changes eligibility is received again, but should only be counted
+			// once.
+			if joined[nodeID] {
+				continue
+			}
+			joined[nodeID] = true
+
+			metrics.MeasureSinceWithLabels([]string{"scale", "out", "node", "ready"}, start,
+				[]metrics.Label{{Name: "class", Value: req.Policy.Class}})
+
+			b.sendNodeEvent(req.ID, nodeID, state.ScaleStatusCompleted,
+				fmt.Sprintf("node %s joined class after %s, %v of %v nodes ready",
+					nodeID, time.Since(start).Round(time.Second), len(joined), expected))
+
+		case <-timer.C:
+			return fmt.Errorf("%v: %v of %v nodes ready within %s",
+				errScaleOutJoinTimeout, len(joined), expected, timeout)
+
+		case <-ctx.Done():
+			return fmt.Errorf("%v: %v of %v nodes ready: %v",
+				errScalingActivityAborted, len(joined), expected, ctx.Err())
+		}
+	}
+	return nil
+}
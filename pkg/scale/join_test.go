@@ -0,0 +1,80 @@
+package scale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackend_awaitNodeJoins(t *testing.T) {
+	testCases := []struct {
+		inputJoins    []string
+		expectedNodes []string
+		expectError   bool
+		name          string
+	}{
+		{
+			inputJoins:    []string{"node-a", "node-b"},
+			expectedNodes: []string{"node-a", "node-b"},
+			expectError:   false,
+			name:          "all nodes joined",
+		},
+		{
+			inputJoins:    []string{"node-a", "node-a", "node-b"},
+			expectedNodes: []string{"node-a", "node-b"},
+			expectError:   false,
+			name:          "node received more than once",
+		},
+		{
+			inputJoins:    []string{"node-a"},
+			expectedNodes: []string{"node-a"},
+			expectError:   true,
+			name:          "nodes did not join before timeout",
+		},
+	}
+
+	for _, tc := range testCases {
+		b := &Backend{eventChan: make(chan *state.EventMessage, 10)}
+		req := &state.ScalingRequest{
+			Direction: state.ScaleDirectionOut,
+			Policy:    &state.ClientScalingPolicy{Class: "test-class", ScaleOutCount: 2},
+		}
+
+		joins := make(chan string, len(tc.inputJoins))
+		for _, id := range tc.inputJoins {
+			joins <- id
+		}
+
+		err := b.awaitNodeJoins(context.Background(), req, joins, time.Now(), 50*time.Millisecond)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+
+		assert.Len(t, b.eventChan, len(tc.expectedNodes), tc.name)
+		for _, id := range tc.expectedNodes {
+			event := <-b.eventChan
+			assert.Equal(t, state.ScaleStatusCompleted, event.NodeStatus, tc.name)
+			assert.Equal(t, id, event.NodeID, tc.name)
+		}
+	}
+}
+
+func TestBackend_awaitNodeJoinsCancelled(t *testing.T) {
+	b := &Backend{eventChan: make(chan *state.EventMessage, 10)}
+	req := &state.ScalingRequest{
+		Direction: state.ScaleDirectionOut,
+		Policy:    &state.ClientScalingPolicy{Class: "test-class", ScaleOutCount: 2},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := b.awaitNodeJoins(ctx, req, make(chan string), time.Now(), time.Minute)
+	assert.NotNil(t, err)
+	assert.Len(t, b.eventChan, 0)
+}
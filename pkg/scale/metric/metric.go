@@ -0,0 +1,24 @@
+package metric
+
+import (
+	"errors"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// ErrValueUnavailable is returned, optionally wrapped, by a Source when the value of the check is
+// temporarily unavailable. The check is skipped rather than failing the whole evaluation.
+var ErrValueUnavailable = errors.New("metric value unavailable")
+
+// Source is the interface that needs to be implemented by metric sources which provide the value
+// of a policy check during an autoscaling evaluation.
+type Source interface {
+
+	// Name returns the human readable name for the metric source.
+	Name() string
+
+	// Value returns the current value of the check metric for the class, which is compared
+	// against the check threshold. When implementing this function, an error should be returned
+	// if a single value cannot be determined so that the evaluation does not act on partial data.
+	Value(class string, check *state.PolicyCheck) (float64, error)
+}
@@ -0,0 +1,70 @@
+package nomad
+
+import (
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/scale/metric"
+	"github.com/jrasell/chemtrail/pkg/scale/resource"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// Source is the Nomad metric source which uses the class resource stats tracked by the resource
+// handler.
+type Source struct {
+	resourceHandler resource.Handler
+}
+
+// NewNomadSource returns a new Nomad metric source.
+func NewNomadSource(handler resource.Handler) *Source {
+	return &Source{resourceHandler: handler}
+}
+
+// Name satisfies the metric.Source Name interface function.
+func (s *Source) Name() string { return state.MetricSourceNomad.String() }
+
+// Value satisfies the metric.Source Value interface function.
+func (s *Source) Value(class string, check *state.PolicyCheck) (float64, error) {
+	var (
+		stats *resource.AllocatedStats
+		err   error
+	)
+
+	// Checks configured with an aggregation window use the aggregated resource samples, otherwise
+	// the current allocated resource stats are used.
+	if check.AggregationWindow > 0 {
+		stats, err = s.resourceHandler.GetAggregatedClassResourceAllocation(class,
+			time.Duration(check.AggregationWindow)*time.Second, check.GetAggregationFunction())
+	} else {
+		stats, err = s.resourceHandler.GetClassResourceAllocation(class)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if check.Resource.IsUsage() && !stats.UsageCollected {
+		return 0, errors.Wrap(metric.ErrValueUnavailable,
+			"resource usage has not been collected, ensure the usage collector is enabled")
+	}
+
+	switch check.Resource {
+	case state.ScaleResourceCPU:
+		return stats.CPU, nil
+	case state.ScaleResourceMemory:
+		return stats.Memory, nil
+	case state.ScaleResourceDisk:
+		return stats.Disk, nil
+	case state.ScaleResourceNetwork:
+		return stats.Network, nil
+	case state.ScaleResourceDevices:
+		return stats.Devices, nil
+	case state.ScaleResourceQueuedAllocs:
+		return stats.QueuedAllocs, nil
+	case state.ScaleResourceCPUUsed:
+		return stats.CPUUsed, nil
+	case state.ScaleResourceMemoryUsed:
+		return stats.MemoryUsed, nil
+	default:
+		return 0, errors.Errorf("unsupported resource: %s", check.Resource.String())
+	}
+}
@@ -0,0 +1,126 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// queryPath is the Prometheus HTTP API path used to perform instant queries.
+	queryPath = "/api/v1/query"
+
+	// queryTimeout is the maximum time allowed for a query to return.
+	queryTimeout = 10 * time.Second
+
+	resultTypeScalar = "scalar"
+	resultTypeVector = "vector"
+)
+
+// Source is the Prometheus metric source which runs the check PromQL query as an instant query
+// against the configured Prometheus server.
+type Source struct {
+	addr       string
+	logger     zerolog.Logger
+	httpClient *http.Client
+}
+
+// queryResponse is the Prometheus HTTP API response to an instant query.
+type queryResponse struct {
+	Status    string    `json:"status"`
+	ErrorType string    `json:"errorType"`
+	Error     string    `json:"error"`
+	Data      queryData `json:"data"`
+}
+
+type queryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+type vectorSample struct {
+	Value []interface{} `json:"value"`
+}
+
+// NewPrometheusSource returns a new Prometheus metric source which queries the server at the
+// passed address.
+func NewPrometheusSource(log zerolog.Logger, addr string) *Source {
+	httpClient := cleanhttp.DefaultClient()
+	httpClient.Timeout = queryTimeout
+
+	return &Source{
+		addr:       strings.TrimSuffix(addr, "/"),
+		logger:     log.With().Str("metric-source", state.MetricSourcePrometheus.String()).Logger(),
+		httpClient: httpClient,
+	}
+}
+
+// Name satisfies the metric.Source Name interface function.
+func (s *Source) Name() string { return state.MetricSourcePrometheus.String() }
+
+// Value satisfies the metric.Source Value interface function.
+func (s *Source) Value(class string, check *state.PolicyCheck) (float64, error) {
+	s.logger.Debug().
+		Str("node-class", class).
+		Str("query", check.Query).
+		Msg("performing Prometheus instant query")
+
+	resp, err := s.httpClient.Get(s.addr + queryPath + "?" + url.Values{"query": {check.Query}}.Encode())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to call Prometheus API")
+	}
+	defer resp.Body.Close()
+
+	var qr queryResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&qr); err != nil {
+		return 0, errors.Wrap(err, "failed to decode Prometheus API response")
+	}
+
+	if qr.Status != "success" {
+		return 0, errors.Errorf("Prometheus query failed: %s: %s", qr.ErrorType, qr.Error)
+	}
+	return parseResult(qr.Data)
+}
+
+// parseResult returns the single value of the query result. Scalar results are used directly,
+// while vector results must contain exactly one sample.
+func parseResult(data queryData) (float64, error) {
+	var value []interface{}
+
+	switch data.ResultType {
+	case resultTypeScalar:
+		if err := json.Unmarshal(data.Result, &value); err != nil {
+			return 0, errors.Wrap(err, "failed to decode scalar result")
+		}
+	case resultTypeVector:
+		var samples []vectorSample
+		if err := json.Unmarshal(data.Result, &samples); err != nil {
+			return 0, errors.Wrap(err, "failed to decode vector result")
+		}
+		if len(samples) != 1 {
+			return 0, errors.Errorf("query returned %v samples, expected 1", len(samples))
+		}
+		value = samples[0].Value
+	default:
+		return 0, errors.Errorf("unsupported query result type: %s", data.ResultType)
+	}
+
+	// Values are returned as a pair of the evaluation timestamp and the sample value as a string.
+	if len(value) != 2 {
+		return 0, errors.New("query result value is malformed")
+	}
+	str, ok := value[1].(string)
+	if !ok {
+		return 0, errors.New("query result value is malformed")
+	}
+	return strconv.ParseFloat(str, 64)
+}
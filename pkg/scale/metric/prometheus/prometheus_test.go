@@ -0,0 +1,85 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource_Value(t *testing.T) {
+	responses := map[string]string{
+		"scalar(sum(up))": `{"status":"success","data":{"resultType":"scalar","result":[1576573200.1,"42.5"]}}`,
+		"sum(rate(http_requests_total[5m]))": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{},"value":[1576573200.1,"87"]}]}}`,
+		"rate(http_requests_total[5m])": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"job":"a"},"value":[1576573200.1,"1"]},{"metric":{"job":"b"},"value":[1576573200.1,"2"]}]}}`,
+		"absent_metric":   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"up[5m]":          `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		"invalid query((": `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, queryPath, r.URL.Path)
+		resp, ok := responses[r.URL.Query().Get("query")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+
+	source := NewPrometheusSource(zerolog.Nop(), srv.URL+"/")
+
+	testCases := []struct {
+		inputQuery     string
+		expectedOutput float64
+		expectError    bool
+		name           string
+	}{
+		{
+			inputQuery:     "scalar(sum(up))",
+			expectedOutput: 42.5,
+			name:           "scalar result",
+		},
+		{
+			inputQuery:     "sum(rate(http_requests_total[5m]))",
+			expectedOutput: 87,
+			name:           "single sample vector result",
+		},
+		{
+			inputQuery:  "rate(http_requests_total[5m])",
+			expectError: true,
+			name:        "multiple sample vector result",
+		},
+		{
+			inputQuery:  "absent_metric",
+			expectError: true,
+			name:        "empty vector result",
+		},
+		{
+			inputQuery:  "up[5m]",
+			expectError: true,
+			name:        "unsupported matrix result",
+		},
+		{
+			inputQuery:  "invalid query((",
+			expectError: true,
+			name:        "query error",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput, err := source.Value("test-class", &state.PolicyCheck{Source: state.MetricSourcePrometheus, Query: tc.inputQuery})
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -2,6 +2,7 @@ package awsasg
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/external"
@@ -24,6 +25,10 @@ type ClientProvider struct {
 
 const (
 	configKeyASGName = "asg-name"
+
+	// maxInstancesPerCall is the maximum number of instance IDs AWS accepts within a single
+	// DetachInstances call.
+	maxInstancesPerCall = 20
 )
 
 func NewAWSASGProvider(log zerolog.Logger, eventChan chan *state.EventMessage) provider.ClientProvider {
@@ -59,7 +64,7 @@ func (a *ClientProvider) ScaleOut(msg *state.ScalingRequest) error {
 	input := autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(asgName),
 		AvailabilityZones:    asg.AvailabilityZones,
-		DesiredCapacity:      aws.Int64(*asg.DesiredCapacity + int64(msg.Policy.ScaleOutCount)),
+		DesiredCapacity:      aws.Int64(*asg.DesiredCapacity + int64(msg.GetCount())),
 	}
 
 	_, err = a.asgClient.UpdateAutoScalingGroupRequest(&input).Send(context.Background())
@@ -68,31 +73,69 @@ func (a *ClientProvider) ScaleOut(msg *state.ScalingRequest) error {
 }
 
 // ScaleIn satisfies the provider.ClientProvider ScaleIn interface function.
-func (a *ClientProvider) ScaleIn(msg *state.ScalingRequest, id string) error {
+func (a *ClientProvider) ScaleIn(msg *state.ScalingRequest, ids []string) error {
 	asgName, err := a.getProviderConfigValue(msg, configKeyASGName)
 	if err != nil {
 		return err
 	}
 
+	// Instances are detached and terminated in batches of the largest size AWS allows, so the
+	// AutoScaling group is updated as few times as possible. A failed batch does not stop the
+	// remaining batches, as their nodes have already been drained.
+	errs := make(map[string]error)
+
+	for _, batch := range batchInstanceIDs(ids, maxInstancesPerCall) {
+		if err := a.removeInstances(msg, asgName, batch); err != nil {
+			for _, id := range batch {
+				errs[id] = err
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &provider.ScaleInError{Errors: errs}
+	}
+	return nil
+}
+
+// removeInstances detaches the instances from the AutoScaling group, decrementing its desired
+// capacity, and then terminates them.
+func (a *ClientProvider) removeInstances(msg *state.ScalingRequest, asgName string, ids []string) error {
+	instances := aws.String(strings.Join(ids, ", "))
+
 	asgInput := autoscaling.DetachInstancesInput{
 		AutoScalingGroupName:           aws.String(asgName),
-		InstanceIds:                    []string{id},
+		InstanceIds:                    ids,
 		ShouldDecrementDesiredCapacity: aws.Bool(true),
 	}
 
-	_, err = a.asgClient.DetachInstancesRequest(&asgInput).Send(context.Background())
-	a.handleEvent(eventTypeUpdate, err, aws.String(id), msg.ID)
+	_, err := a.asgClient.DetachInstancesRequest(&asgInput).Send(context.Background())
+	a.handleEvent(eventTypeUpdate, err, instances, msg.ID)
 	if err != nil {
 		return err
 	}
 
-	ec2Input := ec2.TerminateInstancesInput{DryRun: aws.Bool(false), InstanceIds: []string{id}}
+	ec2Input := ec2.TerminateInstancesInput{DryRun: aws.Bool(false), InstanceIds: ids}
 
 	_, err = a.ec2Client.TerminateInstancesRequest(&ec2Input).Send(context.Background())
-	a.handleEvent(eventTypeTerminate, err, aws.String(id), msg.ID)
+	a.handleEvent(eventTypeTerminate, err, instances, msg.ID)
 	return err
 }
 
+// batchInstanceIDs splits the instance IDs into batches no larger than the passed size.
+func batchInstanceIDs(ids []string, size int) [][]string {
+	var batches [][]string
+
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batches = append(batches, ids[start:end])
+	}
+	return batches
+}
+
 func (a *ClientProvider) describeAutoScalingGroup(name string) (*autoscaling.AutoScalingGroup, error) {
 	input := autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []string{name}}
 
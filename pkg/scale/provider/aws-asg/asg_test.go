@@ -0,0 +1,40 @@
+package awsasg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_batchInstanceIDs(t *testing.T) {
+	testCases := []struct {
+		inputIDs       []string
+		inputSize      int
+		expectedOutput [][]string
+		name           string
+	}{
+		{
+			inputIDs:       nil,
+			inputSize:      2,
+			expectedOutput: nil,
+			name:           "no instances",
+		},
+		{
+			inputIDs:       []string{"i-1", "i-2"},
+			inputSize:      2,
+			expectedOutput: [][]string{{"i-1", "i-2"}},
+			name:           "single full batch",
+		},
+		{
+			inputIDs:       []string{"i-1", "i-2", "i-3", "i-4", "i-5"},
+			inputSize:      2,
+			expectedOutput: [][]string{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}},
+			name:           "final partial batch",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := batchInstanceIDs(tc.inputIDs, tc.inputSize)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
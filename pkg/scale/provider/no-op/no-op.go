@@ -26,7 +26,7 @@ func NewNoOpProvider(log zerolog.Logger, eventChan chan *state.EventMessage) pro
 func (a *ClientProvider) Name() string { return state.NoOpClientProvider.String() }
 
 // ScaleIn satisfies the provider.ClientProvider ScaleIn interface function.
-func (a *ClientProvider) ScaleIn(req *state.ScalingRequest, _ string) error {
+func (a *ClientProvider) ScaleIn(req *state.ScalingRequest, _ []string) error {
 	return a.notifyWrapper(req)
 }
 
@@ -47,7 +47,8 @@ func (a *ClientProvider) sendScaleLogMessage(req *state.ScalingRequest) {
 	a.log.Info().
 		Str("id", req.ID.String()).
 		Str("direction", req.Direction.String()).
-		Str("target-node", req.TargetNodeID).
+		Int("count", req.GetCount()).
+		Strs("target-nodes", req.TargetNodeIDs).
 		Object("policy", req.Policy).
 		Msg("no-op log notification of scaling activity")
 }
@@ -1,6 +1,12 @@
 package provider
 
-import "github.com/jrasell/chemtrail/pkg/state"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+)
 
 // ClientProvider is the interface that needs to be implemented by providers which are responsible
 // scaling Nomad client machines.
@@ -12,9 +18,11 @@ type ClientProvider interface {
 	// ScaleIn will trigger a scaling in event of the provider. When implementing this function, it
 	// should handle only provider interactions as well as manging activity update event based on
 	// what occurs. When calling this function, all safety checks should have been completed to
-	// ensure no policy parameters are violated. The passed target should be used to identify the
-	// node in a way that the provider can understand.
-	ScaleIn(req *state.ScalingRequest, target string) error
+	// ensure no policy parameters are violated. The passed targets identify the nodes to remove in
+	// a way that the provider can understand, and should be removed within as few interactions as
+	// the provider allows. If only some targets fail to be removed, a *ScaleInError should be
+	// returned so the failures are attributed to the correct nodes.
+	ScaleIn(req *state.ScalingRequest, targets []string) error
 
 	// ScaleOut will trigger a scaling out event of the provider. When implementing this function,
 	// it should handle only provider interactions as well as manging activity update event based
@@ -22,3 +30,25 @@ type ClientProvider interface {
 	// ensure no policy parameters are violated.
 	ScaleOut(req *state.ScalingRequest) error
 }
+
+// ScaleInError is returned by ScaleIn when some of the targets failed to be removed. Targets which
+// are not included were removed successfully.
+type ScaleInError struct {
+
+	// Errors contains the error of each target which failed to be removed, keyed by the target.
+	Errors map[string]error
+}
+
+func (e *ScaleInError) Error() string {
+	targets := make([]string, 0, len(e.Errors))
+	for target := range e.Errors {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	msgs := make([]string, len(targets))
+	for i, target := range targets {
+		msgs[i] = fmt.Sprintf("%s: %v", target, e.Errors[target])
+	}
+	return fmt.Sprintf("failed to remove %v targets: %s", len(targets), strings.Join(msgs, "; "))
+}
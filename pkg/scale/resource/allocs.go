@@ -79,13 +79,14 @@ func (n *updateHandler) handleAllocMessageTerminal(class string, alloc *api.Allo
 
 	// Delete the allocation from our tracking.
 	delete(n.nodePool[class].allocations, alloc.ID)
+	delete(n.nodePool[class].nodes[alloc.NodeID].allocations, alloc.ID)
+	delete(n.nodePool[class].nodes[alloc.NodeID].jobs, alloc.ID)
 
-	n.nodePool[class].nodes[alloc.NodeID].resourceStats.allocatedResources.cpu -= float64(*alloc.Resources.CPU)
-	n.nodePool[class].nodes[alloc.NodeID].resourceStats.allocatedResources.memory -= float64(*alloc.Resources.MemoryMB)
+	r := allocResources(alloc)
+	n.nodePool[class].nodes[alloc.NodeID].resourceStats.allocatedResources.sub(r)
 
 	// Update the class pools resource stats.
-	n.nodePool[class].resourceStats.allocatedResources.cpu -= float64(*alloc.Resources.CPU)
-	n.nodePool[class].resourceStats.allocatedResources.memory -= float64(*alloc.Resources.MemoryMB)
+	n.nodePool[class].resourceStats.allocatedResources.sub(r)
 }
 
 func (n *updateHandler) handleAllocMessageRunning(class string, alloc *api.Allocation) {
@@ -98,15 +99,91 @@ func (n *updateHandler) handleAllocMessageRunning(class string, alloc *api.Alloc
 	}
 
 	// Update the allocated resource stats.
-	n.nodePool[class].nodes[alloc.NodeID].resourceStats.allocatedResources.cpu += float64(*alloc.Resources.CPU)
-	n.nodePool[class].nodes[alloc.NodeID].resourceStats.allocatedResources.memory += float64(*alloc.Resources.MemoryMB)
+	r := allocResources(alloc)
+	n.nodePool[class].nodes[alloc.NodeID].resourceStats.allocatedResources.add(r)
 
 	// Update the class pools resource stats.
-	n.nodePool[class].resourceStats.allocatedResources.cpu += float64(*alloc.Resources.CPU)
-	n.nodePool[class].resourceStats.allocatedResources.memory += float64(*alloc.Resources.MemoryMB)
+	n.nodePool[class].resourceStats.allocatedResources.add(r)
 
 	n.nodePool[class].allocations[alloc.ID] = alloc.ClientStatus
+	n.nodePool[class].nodes[alloc.NodeID].allocations[alloc.ID] = r
+
+	// Track the job of the allocation, so nodes running protected jobs can be identified.
+	node := n.nodePool[class].nodes[alloc.NodeID]
+	if node.jobs == nil {
+		node.jobs = make(map[string]*allocJob)
+	}
+	node.jobs[alloc.ID] = newAllocJob(alloc)
 
 	// Our work here is done.
 	n.nodePoolLock.Unlock()
 }
+
+// newAllocJob builds the job details of the allocation. The job ID and type are available on the
+// allocation itself, whereas the meta requires the job to be included.
+func newAllocJob(alloc *api.Allocation) *allocJob {
+	job := allocJob{id: alloc.JobID}
+
+	if alloc.Job != nil {
+		if alloc.Job.Type != nil {
+			job.jobType = *alloc.Job.Type
+		}
+		job.meta = alloc.Job.Meta
+	}
+	return &job
+}
+
+// allocResources calculates the resources allocated to the allocation. Devices are not included
+// within the allocation level resources, therefore these are summed from the task resources.
+func allocResources(alloc *api.Allocation) *resources {
+	r := resources{}
+
+	if alloc.Resources != nil {
+		if alloc.Resources.CPU != nil {
+			r.cpu = float64(*alloc.Resources.CPU)
+		}
+		if alloc.Resources.MemoryMB != nil {
+			r.memory = float64(*alloc.Resources.MemoryMB)
+		}
+		if alloc.Resources.DiskMB != nil {
+			r.disk = float64(*alloc.Resources.DiskMB)
+		}
+		r.network = networkMBits(alloc.Resources.Networks)
+	}
+
+	for _, task := range alloc.TaskResources {
+		if task != nil {
+			r.devices += requestedDevices(task.Devices)
+		}
+	}
+	return &r
+}
+
+// networkMBits sums the bandwidth of the network resources.
+func networkMBits(networks []*api.NetworkResource) float64 {
+	var out float64
+
+	for _, network := range networks {
+		if network != nil && network.MBits != nil {
+			out += float64(*network.MBits)
+		}
+	}
+	return out
+}
+
+// requestedDevices sums the number of device instances requested.
+func requestedDevices(devices []*api.RequestedDevice) float64 {
+	var out float64
+
+	for _, device := range devices {
+		if device == nil {
+			continue
+		}
+		if device.Count == nil {
+			out++
+			continue
+		}
+		out += float64(*device.Count)
+	}
+	return out
+}
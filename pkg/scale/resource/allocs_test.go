@@ -29,8 +29,9 @@ func Test_updateHandler_handleAllocMessageTerminal(t *testing.T) {
 						class: "test-class",
 						nodes: map[string]*nodeInfo{
 							"test-node": {
-								ID:    "test-node",
-								class: "test-class",
+								ID:          "test-node",
+								class:       "test-class",
+								allocations: map[string]*resources{"test-alloc": {cpu: 500, memory: 256}},
 								resourceStats: &resourceStats{
 									allocatableResources: &resources{cpu: 5182, memory: 985},
 									allocatedResources:   &resources{cpu: 500, memory: 256},
@@ -50,8 +51,9 @@ func Test_updateHandler_handleAllocMessageTerminal(t *testing.T) {
 					class: "test-class",
 					nodes: map[string]*nodeInfo{
 						"test-node": {
-							ID:    "test-node",
-							class: "test-class",
+							ID:          "test-node",
+							class:       "test-class",
+							allocations: map[string]*resources{},
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 0, memory: 0},
@@ -82,8 +84,9 @@ func Test_updateHandler_handleAllocMessageTerminal(t *testing.T) {
 						class: "test-class",
 						nodes: map[string]*nodeInfo{
 							"test-node": {
-								ID:    "test-node",
-								class: "test-class",
+								ID:          "test-node",
+								class:       "test-class",
+								allocations: map[string]*resources{"existing-alloc": {cpu: 500, memory: 256}, "removal-alloc": {cpu: 500, memory: 256}},
 								resourceStats: &resourceStats{
 									allocatableResources: &resources{cpu: 5182, memory: 985},
 									allocatedResources:   &resources{cpu: 1000, memory: 512},
@@ -103,8 +106,9 @@ func Test_updateHandler_handleAllocMessageTerminal(t *testing.T) {
 					class: "test-class",
 					nodes: map[string]*nodeInfo{
 						"test-node": {
-							ID:    "test-node",
-							class: "test-class",
+							ID:          "test-node",
+							class:       "test-class",
+							allocations: map[string]*resources{"existing-alloc": {cpu: 500, memory: 256}},
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 500, memory: 256},
@@ -140,7 +144,9 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 			inputAlloc: &api.Allocation{
 				ID:           "test-alloc",
 				NodeID:       "test-node",
+				JobID:        "test-job",
 				ClientStatus: "running",
+				Job:          &api.Job{Type: stringToPointer("service"), Meta: map[string]string{"team": "data"}},
 				Resources:    &api.Resources{CPU: intToPointer(500), MemoryMB: intToPointer(256)},
 			},
 			inputClass: "test-class",
@@ -150,8 +156,9 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 						class: "test-class",
 						nodes: map[string]*nodeInfo{
 							"test-node": {
-								ID:    "test-node",
-								class: "test-class",
+								ID:          "test-node",
+								class:       "test-class",
+								allocations: map[string]*resources{},
 								resourceStats: &resourceStats{
 									allocatableResources: &resources{cpu: 5182, memory: 985},
 									allocatedResources:   &resources{cpu: 0, memory: 0},
@@ -171,8 +178,12 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 					class: "test-class",
 					nodes: map[string]*nodeInfo{
 						"test-node": {
-							ID:    "test-node",
-							class: "test-class",
+							ID:          "test-node",
+							class:       "test-class",
+							allocations: map[string]*resources{"test-alloc": {cpu: 500, memory: 256}},
+							jobs: map[string]*allocJob{
+								"test-alloc": {id: "test-job", jobType: "service", meta: map[string]string{"team": "data"}},
+							},
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 500, memory: 256},
@@ -193,6 +204,7 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 			inputAlloc: &api.Allocation{
 				ID:           "new-alloc",
 				NodeID:       "test-node",
+				JobID:        "new-job",
 				ClientStatus: "running",
 				Resources:    &api.Resources{CPU: intToPointer(500), MemoryMB: intToPointer(256)},
 			},
@@ -203,8 +215,10 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 						class: "test-class",
 						nodes: map[string]*nodeInfo{
 							"test-node": {
-								ID:    "test-node",
-								class: "test-class",
+								ID:          "test-node",
+								class:       "test-class",
+								allocations: map[string]*resources{"existing-alloc": {cpu: 500, memory: 256}},
+								jobs:        map[string]*allocJob{"existing-alloc": {id: "existing-job"}},
 								resourceStats: &resourceStats{
 									allocatableResources: &resources{cpu: 5182, memory: 985},
 									allocatedResources:   &resources{cpu: 500, memory: 256},
@@ -224,8 +238,10 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 					class: "test-class",
 					nodes: map[string]*nodeInfo{
 						"test-node": {
-							ID:    "test-node",
-							class: "test-class",
+							ID:          "test-node",
+							class:       "test-class",
+							allocations: map[string]*resources{"existing-alloc": {cpu: 500, memory: 256}, "new-alloc": {cpu: 500, memory: 256}},
+							jobs:        map[string]*allocJob{"existing-alloc": {id: "existing-job"}, "new-alloc": {id: "new-job"}},
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 1000, memory: 512},
@@ -250,3 +266,47 @@ func Test_updateHandler_handleAllocMessageRunning(t *testing.T) {
 }
 
 func intToPointer(i int) *int { return &i }
+
+func uint64ToPointer(i uint64) *uint64 { return &i }
+
+func Test_allocResources(t *testing.T) {
+	testCases := []struct {
+		inputAlloc     *api.Allocation
+		expectedOutput *resources
+		name           string
+	}{
+		{
+			inputAlloc: &api.Allocation{
+				Resources: &api.Resources{CPU: intToPointer(500), MemoryMB: intToPointer(256)},
+			},
+			expectedOutput: &resources{cpu: 500, memory: 256},
+			name:           "cpu and memory only",
+		},
+		{
+			inputAlloc: &api.Allocation{
+				Resources: &api.Resources{
+					CPU:      intToPointer(500),
+					MemoryMB: intToPointer(256),
+					DiskMB:   intToPointer(1024),
+					Networks: []*api.NetworkResource{{MBits: intToPointer(10)}, {MBits: intToPointer(5)}},
+				},
+				TaskResources: map[string]*api.Resources{
+					"cuda": {Devices: []*api.RequestedDevice{{Name: "nvidia/gpu", Count: uint64ToPointer(2)}}},
+					"tail": {Devices: []*api.RequestedDevice{{Name: "fpga"}}},
+				},
+			},
+			expectedOutput: &resources{cpu: 500, memory: 256, disk: 1024, network: 15, devices: 3},
+			name:           "disk, network and device resources",
+		},
+		{
+			inputAlloc:     &api.Allocation{},
+			expectedOutput: &resources{},
+			name:           "no resources",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := allocResources(tc.inputAlloc)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -0,0 +1,182 @@
+package resource
+
+import (
+	"sort"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// SimulateClassScaleOut satisfies the SimulateClassScaleOut function on the Handler interface.
+func (h *handler) SimulateClassScaleOut(class string) (*state.ScaleOutSimulation, error) {
+	h.nodeManager.nodePoolLock.RLock()
+	defer h.nodeManager.nodePoolLock.RUnlock()
+
+	classInfo, ok := h.nodeManager.nodePool[class]
+	if !ok || len(classInfo.nodes) < 1 {
+		return nil, errors.New("no nodes of class found")
+	}
+
+	var (
+		free     []*resources
+		template *resources
+	)
+
+	for _, node := range classInfo.nodes {
+		free = append(free, &resources{
+			cpu:    node.resourceStats.allocatableResources.cpu - node.resourceStats.allocatedResources.cpu,
+			memory: node.resourceStats.allocatableResources.memory - node.resourceStats.allocatedResources.memory,
+		})
+
+		// New nodes are shaped like the smallest node within the class, so that the simulation
+		// does not underestimate the number of nodes required.
+		if template == nil || node.resourceStats.allocatableResources.memory < template.memory {
+			template = node.resourceStats.allocatableResources
+		}
+	}
+
+	return simulateScaleOut(free, template, h.nodeManager.getClassQueuedAsks(class)), nil
+}
+
+// simulateScaleOut performs a first-fit decreasing bin-packing simulation of the queued asks. Each
+// allocation is placed onto the free capacity of the existing nodes if possible, otherwise onto
+// new nodes with the allocatable resources of the template. Asks with unknown resources are not
+// included within the simulation.
+func simulateScaleOut(free []*resources, template *resources, asks []*queuedAsk) *state.ScaleOutSimulation {
+	sim := state.ScaleOutSimulation{}
+
+	var allocs []resources
+
+	for _, ask := range asks {
+		if ask.resources == nil {
+			continue
+		}
+		for i := 0; i < ask.count; i++ {
+			allocs = append(allocs, *ask.resources)
+		}
+	}
+	sim.QueuedAllocs = len(allocs)
+
+	// Place the largest allocations first, which gives a tighter packing.
+	sort.SliceStable(allocs, func(i, j int) bool {
+		if allocs[i].memory != allocs[j].memory {
+			return allocs[i].memory > allocs[j].memory
+		}
+		return allocs[i].cpu > allocs[j].cpu
+	})
+
+	// Copy the existing free capacity so the passed resources are not modified.
+	existing := make([]*resources, len(free))
+	for i := range free {
+		existing[i] = &resources{cpu: free[i].cpu, memory: free[i].memory}
+	}
+
+	var newNodes []*resources
+
+	for i := range allocs {
+		if placeAlloc(existing, &allocs[i]) {
+			sim.PlacedExisting++
+			continue
+		}
+		if placeAlloc(newNodes, &allocs[i]) {
+			sim.PlacedNew++
+			continue
+		}
+
+		// If the allocation does not fit on an empty node, adding nodes will never allow it to be
+		// placed.
+		if !fits(template, &allocs[i]) {
+			sim.Unplaceable++
+			continue
+		}
+		newNodes = append(newNodes, &resources{
+			cpu:    template.cpu - allocs[i].cpu,
+			memory: template.memory - allocs[i].memory,
+		})
+		sim.PlacedNew++
+	}
+
+	sim.NodesRequired = len(newNodes)
+	return &sim
+}
+
+// placeAlloc attempts to place the allocation onto the first node with enough free capacity,
+// subtracting the allocation resources from the node if successful.
+func placeAlloc(nodes []*resources, alloc *resources) bool {
+	for _, node := range nodes {
+		if fits(node, alloc) {
+			node.cpu -= alloc.cpu
+			node.memory -= alloc.memory
+			return true
+		}
+	}
+	return false
+}
+
+// fits identifies whether the allocation resources fit within the free node resources.
+func fits(node, alloc *resources) bool {
+	return alloc.cpu <= node.cpu && alloc.memory <= node.memory
+}
+
+// CheckNodeRemoval satisfies the CheckNodeRemoval function on the Handler interface.
+func (h *handler) CheckNodeRemoval(class string, nodeIDs []string) (int, error) {
+	h.nodeManager.nodePoolLock.RLock()
+	defer h.nodeManager.nodePoolLock.RUnlock()
+
+	classInfo, ok := h.nodeManager.nodePool[class]
+	if !ok {
+		return 0, errors.New("no nodes of class found")
+	}
+
+	removed := make(map[string]bool, len(nodeIDs))
+	var allocs []*resources
+
+	for _, nodeID := range nodeIDs {
+		target, ok := classInfo.nodes[nodeID]
+		if !ok {
+			return 0, errors.Errorf("node %s not found in class", nodeID)
+		}
+		removed[nodeID] = true
+
+		for _, alloc := range target.allocations {
+			allocs = append(allocs, alloc)
+		}
+	}
+
+	var free []*resources
+
+	for id, node := range classInfo.nodes {
+		if removed[id] {
+			continue
+		}
+		free = append(free, &resources{
+			cpu:    node.resourceStats.allocatableResources.cpu - node.resourceStats.allocatedResources.cpu,
+			memory: node.resourceStats.allocatableResources.memory - node.resourceStats.allocatedResources.memory,
+		})
+	}
+	return countUnplaceable(free, allocs), nil
+}
+
+// countUnplaceable performs a first-fit decreasing placement of the allocations onto the free
+// capacity of the nodes, returning the number of allocations which could not be placed. The free
+// capacity of the passed nodes is consumed by the placement.
+func countUnplaceable(free []*resources, allocs []*resources) int {
+	sorted := make([]*resources, len(allocs))
+	copy(sorted, allocs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].memory != sorted[j].memory {
+			return sorted[i].memory > sorted[j].memory
+		}
+		return sorted[i].cpu > sorted[j].cpu
+	})
+
+	var unplaceable int
+
+	for _, alloc := range sorted {
+		if !placeAlloc(free, alloc) {
+			unplaceable++
+		}
+	}
+	return unplaceable
+}
@@ -0,0 +1,174 @@
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_simulateScaleOut(t *testing.T) {
+	template := &resources{cpu: 4000, memory: 8192}
+
+	testCases := []struct {
+		inputFree      []*resources
+		inputAsks      []*queuedAsk
+		expectedOutput *state.ScaleOutSimulation
+		name           string
+	}{
+		{
+			inputFree:      []*resources{{cpu: 1000, memory: 1024}},
+			inputAsks:      nil,
+			expectedOutput: &state.ScaleOutSimulation{},
+			name:           "no queued asks",
+		},
+		{
+			inputFree: []*resources{{cpu: 1000, memory: 1024}, {cpu: 500, memory: 512}},
+			inputAsks: []*queuedAsk{{class: "batch", count: 3, resources: &resources{cpu: 500, memory: 512}}},
+			expectedOutput: &state.ScaleOutSimulation{
+				QueuedAllocs:   3,
+				PlacedExisting: 3,
+			},
+			name: "all asks fit on existing nodes",
+		},
+		{
+			inputFree: []*resources{{cpu: 1000, memory: 1024}},
+			inputAsks: []*queuedAsk{
+				{class: "batch", count: 5, resources: &resources{cpu: 1000, memory: 3072}},
+				{class: "batch", count: 2, resources: &resources{cpu: 500, memory: 1024}},
+			},
+			expectedOutput: &state.ScaleOutSimulation{
+				QueuedAllocs:   7,
+				PlacedExisting: 1,
+				PlacedNew:      6,
+				NodesRequired:  3,
+			},
+			name: "largest asks placed first onto new nodes",
+		},
+		{
+			inputFree: []*resources{{cpu: 1000, memory: 1024}},
+			inputAsks: []*queuedAsk{
+				{class: "batch", count: 2, resources: &resources{cpu: 500, memory: 16384}},
+				{class: "batch", count: 4, resources: nil},
+				{class: "batch", count: 1, resources: &resources{cpu: 3000, memory: 2048}},
+			},
+			expectedOutput: &state.ScaleOutSimulation{
+				QueuedAllocs:  3,
+				PlacedNew:     1,
+				Unplaceable:   2,
+				NodesRequired: 1,
+			},
+			name: "unplaceable and unknown asks",
+		},
+	}
+
+	for _, tc := range testCases {
+		free := make([]*resources, len(tc.inputFree))
+		for i := range tc.inputFree {
+			free[i] = &resources{cpu: tc.inputFree[i].cpu, memory: tc.inputFree[i].memory}
+		}
+		actualOutput := simulateScaleOut(free, template, tc.inputAsks)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+		assert.Equal(t, tc.inputFree, free, tc.name)
+	}
+}
+
+func Test_countUnplaceable(t *testing.T) {
+	testCases := []struct {
+		inputFree      []*resources
+		inputAllocs    []*resources
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputFree:      []*resources{{cpu: 1000, memory: 1024}},
+			inputAllocs:    nil,
+			expectedOutput: 0,
+			name:           "node with no allocations",
+		},
+		{
+			inputFree:      []*resources{{cpu: 1000, memory: 512}, {cpu: 1000, memory: 1024}},
+			inputAllocs:    []*resources{{cpu: 500, memory: 512}, {cpu: 500, memory: 1024}},
+			expectedOutput: 0,
+			name:           "allocations fit across remaining nodes",
+		},
+		{
+			inputFree:      []*resources{{cpu: 4000, memory: 1024}, {cpu: 4000, memory: 1024}},
+			inputAllocs:    []*resources{{cpu: 500, memory: 640}, {cpu: 500, memory: 640}, {cpu: 500, memory: 640}},
+			expectedOutput: 1,
+			name:           "class free capacity sufficient but fragmented",
+		},
+		{
+			inputFree:      nil,
+			inputAllocs:    []*resources{{cpu: 100, memory: 128}},
+			expectedOutput: 1,
+			name:           "no remaining nodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := countUnplaceable(tc.inputFree, tc.inputAllocs)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_handler_CheckNodeRemoval(t *testing.T) {
+	newNode := func(id string, allocs ...*resources) *nodeInfo {
+		node := &nodeInfo{
+			ID:          id,
+			allocations: map[string]*resources{},
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1024},
+				allocatedResources:   &resources{},
+			},
+		}
+		for i, alloc := range allocs {
+			node.allocations[fmt.Sprintf("%s-alloc-%v", id, i)] = alloc
+			node.resourceStats.allocatedResources.cpu += alloc.cpu
+			node.resourceStats.allocatedResources.memory += alloc.memory
+		}
+		return node
+	}
+
+	h := &handler{nodeManager: &updateHandler{
+		nodePool: map[string]*classInfo{"test-class": {nodes: map[string]*nodeInfo{
+			"node-a": newNode("node-a", &resources{cpu: 200, memory: 512}),
+			"node-b": newNode("node-b", &resources{cpu: 200, memory: 512}),
+			"node-c": newNode("node-c", &resources{cpu: 200, memory: 512}),
+		}}},
+	}}
+
+	testCases := []struct {
+		inputNodeIDs   []string
+		expectedOutput int
+		expectError    bool
+		name           string
+	}{
+		{
+			inputNodeIDs:   []string{"node-a"},
+			expectedOutput: 0,
+			name:           "single node removal",
+		},
+		{
+			inputNodeIDs:   []string{"node-a", "node-b"},
+			expectedOutput: 1,
+			name:           "multiple node removal exhausts remaining capacity",
+		},
+		{
+			inputNodeIDs: []string{"node-a", "node-d"},
+			expectError:  true,
+			name:         "node not found in class",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput, err := h.CheckNodeRemoval("test-class", tc.inputNodeIDs)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
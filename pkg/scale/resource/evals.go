@@ -0,0 +1,229 @@
+package resource
+
+import (
+	"github.com/hashicorp/nomad/api"
+)
+
+const (
+	// evalStatusBlocked is the Nomad evaluation status used when an evaluation is waiting for
+	// cluster resources to become available before it can make placements.
+	evalStatusBlocked = "blocked"
+
+	// nodeClassConstraintTarget is the Nomad interpolation target used within job constraints to
+	// target the class of a node.
+	nodeClassConstraintTarget = "${node.class}"
+)
+
+func (n *updateHandler) runEvalUpdateHandler() {
+	n.logger.Info().Msg("starting Chemtrail Nomad evaluation update handler")
+
+	for {
+		select {
+		case <-n.shutdownChan:
+			n.logger.Info().Msg("shutting down Chemtrail evaluation update handler")
+			return
+		case msg := <-n.evalUpdateChan:
+
+			// Evaluation updates are processed serially, in the order the watcher sends them, so
+			// that an older update cannot re-add an evaluation after a newer update removed it.
+			n.handleEvalMessage(msg)
+		}
+	}
+}
+
+// handleEvalMessage processes evaluation updates in order to track allocations which Nomad has
+// been unable to place. When the scheduler fails to place allocations it creates a blocked
+// evaluation, containing the placement failures, which is tracked until it is no longer blocked.
+func (n *updateHandler) handleEvalMessage(msg interface{}) {
+	eval, ok := msg.(*api.Evaluation)
+	if !ok {
+		n.logger.Error().Msg("received unexpected evaluation update message type")
+		return
+	}
+	n.logger.Debug().
+		Str("eval-id", eval.ID).
+		Str("eval-status", eval.Status).
+		Msg("received evaluation update message to handle")
+
+	// An evaluation which is no longer blocked should be removed from tracking as the scheduler
+	// has either placed the allocations or will create a new blocked evaluation. Only blocked
+	// evaluations are tracked, as the evaluation which created a blocked evaluation never changes
+	// once complete and would otherwise keep the blocked evaluation tracked forever.
+	if eval.Status != evalStatusBlocked {
+		n.blockedEvalsLock.Lock()
+		delete(n.blockedEvals, eval.ID)
+		n.blockedEvalsLock.Unlock()
+		return
+	}
+
+	if len(eval.FailedTGAllocs) == 0 {
+		return
+	}
+
+	job, _, err := n.nomad.Client.Jobs().Info(eval.JobID, &api.QueryOptions{Namespace: eval.Namespace})
+	if err != nil {
+		n.logger.Error().
+			Err(err).
+			Str("eval-id", eval.ID).
+			Str("job-id", eval.JobID).
+			Msg("failed to call Nomad API for job info")
+	}
+
+	asks := blockedEvalQueuedAsks(eval, job)
+
+	for _, ask := range asks {
+		n.logger.Debug().
+			Str("eval-id", eval.ID).
+			Str("job-id", eval.JobID).
+			Str("node-class", ask.class).
+			Int("queued-allocs", ask.count).
+			Msg("tracking blocked evaluation queued allocations")
+	}
+
+	n.blockedEvalsLock.Lock()
+	n.blockedEvals[eval.ID] = asks
+	n.blockedEvalsLock.Unlock()
+}
+
+// getClassQueuedAllocs returns the total number of queued allocations attributed to the class
+// across all the tracked blocked evaluations.
+func (n *updateHandler) getClassQueuedAllocs(class string) int {
+	var total int
+
+	for _, ask := range n.getClassQueuedAsks(class) {
+		total += ask.count
+	}
+	return total
+}
+
+// getClassQueuedAsks returns all the queued asks attributed to the class across all the tracked
+// blocked evaluations.
+func (n *updateHandler) getClassQueuedAsks(class string) []*queuedAsk {
+	n.blockedEvalsLock.RLock()
+	defer n.blockedEvalsLock.RUnlock()
+
+	var out []*queuedAsk
+
+	for _, asks := range n.blockedEvals {
+		for _, ask := range asks {
+			if ask.class == class {
+				out = append(out, ask)
+			}
+		}
+	}
+	return out
+}
+
+// queuedAsk represents a number of allocations of a task group which Nomad has been unable to
+// place and which are attributed to a node class.
+type queuedAsk struct {
+	class string
+	count int
+
+	// resources is the resource ask of a single allocation of the task group. This will be nil
+	// if the job could not be read and the resource ask is unknown.
+	resources *resources
+}
+
+// blockedEvalQueuedAsks calculates the queued allocation asks per node class from the failed task
+// group allocations of the evaluation.
+func blockedEvalQueuedAsks(eval *api.Evaluation, job *api.Job) []*queuedAsk {
+	var out []*queuedAsk
+
+	for tg, metric := range eval.FailedTGAllocs {
+		if metric == nil {
+			continue
+		}
+
+		// The number of queued allocations is taken from the evaluation if available, otherwise
+		// the failure metric which includes coalesced failures is used.
+		queued := eval.QueuedAllocations[tg]
+		if failed := metric.CoalescedFailures + 1; failed > queued {
+			queued = failed
+		}
+
+		ask := taskGroupResources(job, tg)
+
+		for _, class := range taskGroupClasses(job, tg, metric) {
+			out = append(out, &queuedAsk{class: class, count: queued, resources: ask})
+		}
+	}
+	return out
+}
+
+// taskGroupResources calculates the resources required by a single allocation of the task group
+// by summing the resources of each task. If the job or task group is not found, nil is returned.
+func taskGroupResources(job *api.Job, tg string) *resources {
+	if job == nil {
+		return nil
+	}
+
+	for _, group := range job.TaskGroups {
+		if group.Name == nil || *group.Name != tg {
+			continue
+		}
+
+		r := resources{}
+
+		for _, task := range group.Tasks {
+			if task.Resources == nil {
+				continue
+			}
+			if task.Resources.CPU != nil {
+				r.cpu += float64(*task.Resources.CPU)
+			}
+			if task.Resources.MemoryMB != nil {
+				r.memory += float64(*task.Resources.MemoryMB)
+			}
+		}
+		return &r
+	}
+	return nil
+}
+
+// taskGroupClasses identifies the node classes which a task group is attempting to be placed on.
+// Explicit node class constraints on the task group or job are preferred, falling back to the
+// classes which the scheduler found to be exhausted.
+func taskGroupClasses(job *api.Job, tg string, metric *api.AllocationMetric) []string {
+	if job != nil {
+		for _, group := range job.TaskGroups {
+			if group.Name == nil || *group.Name != tg {
+				continue
+			}
+			if class := nodeClassConstraint(group.Constraints); class != "" {
+				return []string{class}
+			}
+		}
+		if class := nodeClassConstraint(job.Constraints); class != "" {
+			return []string{class}
+		}
+	}
+
+	var out []string
+
+	for class, count := range metric.ClassExhausted {
+		if count < 1 {
+			continue
+		}
+		if class == "" {
+			class = defaultNodeClass
+		}
+		out = append(out, class)
+	}
+	return out
+}
+
+// nodeClassConstraint returns the node class from an equality constraint on the node class if
+// one is found.
+func nodeClassConstraint(constraints []*api.Constraint) string {
+	for _, c := range constraints {
+		if c == nil || c.LTarget != nodeClassConstraintTarget {
+			continue
+		}
+		switch c.Operand {
+		case "", "=", "==", "is":
+			return c.RTarget
+		}
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_blockedEvalQueuedAsks(t *testing.T) {
+	testCases := []struct {
+		inputEval      *api.Evaluation
+		inputJob       *api.Job
+		expectedOutput []*queuedAsk
+		name           string
+	}{
+		{
+			inputEval: &api.Evaluation{
+				FailedTGAllocs: map[string]*api.AllocationMetric{
+					"cache": {CoalescedFailures: 2, ClassExhausted: map[string]int{"high-memory": 3}},
+				},
+			},
+			inputJob:       nil,
+			expectedOutput: []*queuedAsk{{class: "high-memory", count: 3}},
+			name:           "no job uses exhausted classes and coalesced failures",
+		},
+		{
+			inputEval: &api.Evaluation{
+				QueuedAllocations: map[string]int{"cache": 5},
+				FailedTGAllocs: map[string]*api.AllocationMetric{
+					"cache": {ClassExhausted: map[string]int{"high-memory": 3, "": 1}},
+				},
+			},
+			inputJob: &api.Job{
+				TaskGroups: []*api.TaskGroup{{
+					Name: stringToPointer("cache"),
+					Tasks: []*api.Task{
+						{Resources: &api.Resources{CPU: intToPointer(500), MemoryMB: intToPointer(256)}},
+						{Resources: &api.Resources{CPU: intToPointer(100), MemoryMB: intToPointer(64)}},
+					},
+					Constraints: []*api.Constraint{
+						{LTarget: "${attr.kernel.name}", RTarget: "linux", Operand: "="},
+						{LTarget: "${node.class}", RTarget: "batch", Operand: "="},
+					},
+				}},
+			},
+			expectedOutput: []*queuedAsk{{class: "batch", count: 5, resources: &resources{cpu: 600, memory: 320}}},
+			name:           "task group class constraint with queued allocations",
+		},
+		{
+			inputEval: &api.Evaluation{
+				QueuedAllocations: map[string]int{"cache": 1, "web": 4},
+				FailedTGAllocs: map[string]*api.AllocationMetric{
+					"cache": {},
+					"web":   {},
+				},
+			},
+			inputJob: &api.Job{
+				Constraints: []*api.Constraint{{LTarget: "${node.class}", RTarget: "batch", Operand: "=="}},
+				TaskGroups:  []*api.TaskGroup{{Name: stringToPointer("cache")}, {Name: stringToPointer("web")}},
+			},
+			expectedOutput: []*queuedAsk{
+				{class: "batch", count: 1, resources: &resources{}},
+				{class: "batch", count: 4, resources: &resources{}},
+			},
+			name: "job class constraint across multiple task groups",
+		},
+		{
+			inputEval: &api.Evaluation{
+				FailedTGAllocs: map[string]*api.AllocationMetric{
+					"cache": {ClassExhausted: map[string]int{"": 1}},
+				},
+			},
+			inputJob:       &api.Job{},
+			expectedOutput: []*queuedAsk{{class: "chemtrail-default", count: 1}},
+			name:           "exhausted node without class uses default class",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := blockedEvalQueuedAsks(tc.inputEval, tc.inputJob)
+		assert.ElementsMatch(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_updateHandler_getClassQueuedAllocs(t *testing.T) {
+	h := &updateHandler{
+		blockedEvals: map[string][]*queuedAsk{
+			"eval-1": {{class: "batch", count: 2}, {class: "web", count: 1}},
+			"eval-2": {{class: "batch", count: 3}},
+		},
+	}
+
+	assert.Equal(t, 5, h.getClassQueuedAllocs("batch"))
+	assert.Equal(t, 1, h.getClassQueuedAllocs("web"))
+	assert.Equal(t, 0, h.getClassQueuedAllocs("not-found"))
+}
+
+func Test_updateHandler_handleEvalMessage(t *testing.T) {
+	h := &updateHandler{
+		blockedEvals: map[string][]*queuedAsk{
+			"blocked-eval": {{class: "batch", count: 2}},
+		},
+	}
+
+	// A completed evaluation which created the blocked evaluation must not re-add the blocked
+	// evaluation to tracking, regardless of the order in which the updates are received.
+	h.handleEvalMessage(&api.Evaluation{
+		ID:             "parent-eval",
+		Status:         "complete",
+		BlockedEval:    "blocked-eval",
+		FailedTGAllocs: map[string]*api.AllocationMetric{"batch": {ClassExhausted: map[string]int{"batch": 1}}},
+	})
+	assert.Equal(t, 2, h.getClassQueuedAllocs("batch"))
+
+	h.handleEvalMessage(&api.Evaluation{ID: "blocked-eval", Status: "complete"})
+	assert.Equal(t, 0, h.getClassQueuedAllocs("batch"))
+
+	h.handleEvalMessage(&api.Evaluation{
+		ID:             "parent-eval",
+		Status:         "complete",
+		BlockedEval:    "blocked-eval",
+		FailedTGAllocs: map[string]*api.AllocationMetric{"batch": {ClassExhausted: map[string]int{"batch": 1}}},
+	})
+	assert.Empty(t, h.blockedEvals)
+}
+
+func stringToPointer(s string) *string { return &s }
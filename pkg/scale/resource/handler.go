@@ -2,8 +2,10 @@ package resource
 
 import (
 	"sync"
+	"time"
 
 	"github.com/jrasell/chemtrail/pkg/client"
+	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/rs/zerolog"
 )
 
@@ -32,6 +34,16 @@ type Handler interface {
 	// github.com/hashicorp/nomad/api/.(*Allocation).
 	RunAllocUpdateHandler()
 
+	// GetEvalUpdateChan returns the channel where the evaluation watcher should send updates
+	// regarding the Nomad cluster evaluations.
+	GetEvalUpdateChan() chan interface{}
+
+	// RunEvalUpdateHandler triggers the process which handles evaluation updates and listens on
+	// the channel as returned via GetEvalUpdateChan. When implementing this interface, the process
+	// listening on the channel should expect a type of
+	// github.com/hashicorp/nomad/api/.(*Evaluation).
+	RunEvalUpdateHandler()
+
 	// StopUpdateHandlers is used to stop all the running update handlers within the resource
 	// process.
 	StopUpdateHandlers()
@@ -48,10 +60,50 @@ type Handler interface {
 	// resources.
 	GetClassResourceAllocation(class string) (*AllocatedStats, error)
 
-	// GetLeastAllocatedNodeInClass is used to find the node in the class pool which is the least
-	// allocated. This is the current default and hardcoded mode for scaling in as it reduces the
-	// amount of resources that need to be migrated across the cluster.
-	GetLeastAllocatedNodeInClass(class string) *nodeInfo
+	// RunResourceSampler triggers the process which periodically snapshots the allocated resource
+	// stats of each class into a fixed size buffer.
+	RunResourceSampler()
+
+	// RunUsageCollector triggers the process which periodically collects the actual resource usage
+	// of the running allocations from the Nomad clients, and stores this alongside the allocated
+	// resource stats of each node and class.
+	RunUsageCollector()
+
+	// GetAggregatedClassResourceAllocation is used to calculate the allocated resource stats of
+	// the class by aggregating the samples taken within the window using the passed function. If
+	// no samples are held within the window, the current allocation is returned.
+	GetAggregatedClassResourceAllocation(class string, window time.Duration, fn state.AggregationFunction) (*AllocatedStats, error)
+
+	// SimulateClassScaleOut is used to simulate placing the queued allocations attributed to the
+	// class onto the free capacity of the existing nodes, and then onto new nodes shaped like the
+	// existing nodes. The result details the number of new nodes required.
+	SimulateClassScaleOut(class string) (*state.ScaleOutSimulation, error)
+
+	// CheckNodeRemoval is used to check whether the allocations running on the nodes can be placed
+	// onto the free capacity of the remaining nodes within the class, should the nodes be removed.
+	// The returned int is the number of allocations which would be unable to be placed.
+	CheckNodeRemoval(class string, nodeIDs []string) (int, error)
+
+	// SelectScaleInNodes is used to find the nodes in the class pool which should be removed when
+	// scaling in, using the strategy of the passed selector. A nil selector uses the default
+	// least-allocated strategy. Nodes protected from scaling in, either via their meta or by
+	// running a job matched by the passed protection, are never selected. Up to count nodes are
+	// returned, therefore fewer are returned if not enough nodes can be selected.
+	SelectScaleInNodes(class string, selector *state.ScaleInSelector, protection *state.ScaleInProtection, count int) []*nodeInfo
+
+	// GetRemovableNodeCount returns the number of nodes in the class pool which can be removed
+	// during a scale in activity. Nodes protected from scaling in are not counted.
+	GetRemovableNodeCount(class string, protection *state.ScaleInProtection) int
+
+	// GetNodes returns the status of the nodes tracked within the class pool, sorted by class and
+	// ID. An empty class returns the nodes of all classes.
+	GetNodes(class string) []*state.NodeStatus
+
+	// WatchNodeJoins returns a channel which receives the ID of each node of the class which
+	// becomes ready and eligible, and was registered with Nomad after the passed index. A node
+	// can be received more than once if its eligibility changes. The returned function must be
+	// called to stop the watch once it is no longer required.
+	WatchNodeJoins(class string, index uint64) (<-chan string, func())
 }
 
 type updateHandler struct {
@@ -76,6 +128,20 @@ type updateHandler struct {
 	// allocUpdateChan is where updates from the alloc watcher should be sent for processing.
 	allocUpdateChan chan interface{}
 
+	// evalUpdateChan is where updates from the evaluation watcher should be sent for processing.
+	evalUpdateChan chan interface{}
+
+	// blockedEvals tracks the Nomad evaluations which are blocked due to placement failures. The
+	// map is keyed by the blocked evaluation ID, and the value contains the queued allocation
+	// asks of the evaluation.
+	blockedEvals     map[string][]*queuedAsk
+	blockedEvalsLock sync.RWMutex
+
+	// joinWatchers are the active watches for nodes joining a class, as created by
+	// WatchNodeJoins.
+	joinWatchers     map[*joinWatcher]struct{}
+	joinWatchersLock sync.Mutex
+
 	// shutdownChan is used to coordinate the shutdown of the resource processes in a clean manner.
 	shutdownChan chan struct{}
 }
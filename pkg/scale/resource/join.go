@@ -0,0 +1,57 @@
+package resource
+
+// joinWatcher is an active watch for nodes joining a class, which have been registered with Nomad
+// after the index.
+type joinWatcher struct {
+	class string
+	index uint64
+	ch    chan string
+	done  chan struct{}
+}
+
+// WatchNodeJoins satisfies the WatchNodeJoins function on the Handler interface.
+func (h *handler) WatchNodeJoins(class string, index uint64) (<-chan string, func()) {
+	w := &joinWatcher{
+		class: class,
+		index: index,
+		ch:    make(chan string),
+		done:  make(chan struct{}),
+	}
+
+	h.nodeManager.joinWatchersLock.Lock()
+	if h.nodeManager.joinWatchers == nil {
+		h.nodeManager.joinWatchers = make(map[*joinWatcher]struct{})
+	}
+	h.nodeManager.joinWatchers[w] = struct{}{}
+	h.nodeManager.joinWatchersLock.Unlock()
+
+	stop := func() {
+		h.nodeManager.joinWatchersLock.Lock()
+		if _, ok := h.nodeManager.joinWatchers[w]; ok {
+			delete(h.nodeManager.joinWatchers, w)
+			close(w.done)
+		}
+		h.nodeManager.joinWatchersLock.Unlock()
+	}
+	return w.ch, stop
+}
+
+// notifyNodeJoin sends the ID of the node, which has been added to the node pool, to the watchers
+// of its class. Sends are performed asynchronously so that node updates are not blocked by slow
+// watchers, and are abandoned once the watch is stopped.
+func (n *updateHandler) notifyNodeJoin(node *nodeInfo) {
+	n.joinWatchersLock.Lock()
+	defer n.joinWatchersLock.Unlock()
+
+	for w := range n.joinWatchers {
+		if w.class != node.class || node.createIndex <= w.index {
+			continue
+		}
+		go func(w *joinWatcher) {
+			select {
+			case w.ch <- node.ID:
+			case <-w.done:
+			}
+		}(w)
+	}
+}
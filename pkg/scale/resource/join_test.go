@@ -0,0 +1,38 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_handler_WatchNodeJoins(t *testing.T) {
+	h := &handler{nodeManager: &updateHandler{}}
+
+	joins, stop := h.WatchNodeJoins("test-class", 100)
+	defer stop()
+
+	h.nodeManager.notifyNodeJoin(&nodeInfo{ID: "existing-node", class: "test-class", createIndex: 50})
+	h.nodeManager.notifyNodeJoin(&nodeInfo{ID: "other-class-node", class: "other-class", createIndex: 150})
+	h.nodeManager.notifyNodeJoin(&nodeInfo{ID: "new-node", class: "test-class", createIndex: 150})
+
+	select {
+	case id := <-joins:
+		assert.Equal(t, "new-node", id)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for node join")
+	}
+
+	select {
+	case id := <-joins:
+		t.Fatalf("received unexpected node join %s", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stop()
+	assert.Empty(t, h.nodeManager.joinWatchers)
+
+	// Stopping the watch more than once must be safe.
+	stop()
+}
@@ -1,9 +1,21 @@
 package resource
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/nomad/api"
 )
 
+const (
+	// defaultNodeClass is the class used by Chemtrail for nodes which do not have a class
+	// configured.
+	defaultNodeClass = "chemtrail-default"
+
+	// protectedMetaKey is the Nomad node meta key which, when set to true, protects the node from
+	// being selected for removal during scale in activities.
+	protectedMetaKey = "chemtrail.protected"
+)
+
 func (n *updateHandler) runNodeUpdateHandler() {
 	n.logger.Info().Msg("starting Chemtrail Nomad node update handler")
 
@@ -76,6 +88,11 @@ func (n *updateHandler) handleNodeAvailableMessage(node *api.Node) {
 	if stored != nil {
 		if storedNode, ok := stored.nodes[node.ID]; ok {
 			if node.Status == storedNode.status {
+
+				// The node meta can be updated without the node status changing, therefore the
+				// protection is always refreshed.
+				storedNode.protected = isNodeProtected(node)
+
 				n.logger.Debug().
 					Str("node-id", node.ID).
 					Str("node-status", storedNode.status).
@@ -90,7 +107,11 @@ func (n *updateHandler) handleNodeAvailableMessage(node *api.Node) {
 		ID:          node.ID,
 		status:      node.Status,
 		class:       node.NodeClass,
+		createIndex: node.CreateIndex,
+		attributes:  node.Attributes,
+		protected:   isNodeProtected(node),
 		eligibility: node.SchedulingEligibility,
+		allocations: make(map[string]*resources),
 		resourceStats: &resourceStats{
 			allocatedResources:   &resources{},
 			allocatableResources: n.getNodeAllocatableResources(node),
@@ -101,13 +122,18 @@ func (n *updateHandler) handleNodeAvailableMessage(node *api.Node) {
 	n.logger.Info().
 		Str("node-id", info.ID).
 		Str("node-class", info.class).
+		Bool("node-protected", info.protected).
 		Float64("node-allocatable-cpu", info.resourceStats.allocatableResources.cpu).
 		Float64("node-allocatable-memory", info.resourceStats.allocatableResources.memory).
+		Float64("node-allocatable-disk", info.resourceStats.allocatableResources.disk).
+		Float64("node-allocatable-network", info.resourceStats.allocatableResources.network).
+		Float64("node-allocatable-devices", info.resourceStats.allocatableResources.devices).
 		Msg("added node to Chemtrail internal state")
 
 	// Update the node class pool high level resource tracking stats.
-	n.nodePool[node.NodeClass].resourceStats.allocatableResources.cpu += info.resourceStats.allocatableResources.cpu
-	n.nodePool[node.NodeClass].resourceStats.allocatableResources.memory += info.resourceStats.allocatableResources.memory
+	n.nodePool[node.NodeClass].resourceStats.allocatableResources.add(info.resourceStats.allocatableResources)
+
+	n.notifyNodeJoin(&info)
 }
 
 func (n *updateHandler) handleNodeUnavailableMessage(node *api.Node) {
@@ -124,8 +150,7 @@ func (n *updateHandler) handleNodeUnavailableMessage(node *api.Node) {
 
 		delete(n.nodePool[node.NodeClass].nodes, node.ID)
 
-		n.nodePool[node.NodeClass].resourceStats.allocatableResources.cpu -= info.resourceStats.allocatableResources.cpu
-		n.nodePool[node.NodeClass].resourceStats.allocatableResources.memory -= info.resourceStats.allocatableResources.memory
+		n.nodePool[node.NodeClass].resourceStats.allocatableResources.sub(info.resourceStats.allocatableResources)
 
 		n.nodePoolLock.Unlock()
 
@@ -135,6 +160,13 @@ func (n *updateHandler) handleNodeUnavailableMessage(node *api.Node) {
 	}
 }
 
+// isNodeProtected identifies whether the node meta protects the node from being removed during
+// scale in activities. Values which cannot be parsed as a bool do not protect the node.
+func isNodeProtected(node *api.Node) bool {
+	protected, err := strconv.ParseBool(node.Meta[protectedMetaKey])
+	return err == nil && protected
+}
+
 // checkNodeClass is used to check whether the received node has its Class set. If not, we set the
 // Chemtrail default.
 func (n *updateHandler) checkNodeClass(node *api.Node) {
@@ -142,7 +174,7 @@ func (n *updateHandler) checkNodeClass(node *api.Node) {
 		n.logger.Debug().
 			Str("node-id", node.ID).
 			Msg("node has empty class parameter, using Chemtrail default")
-		node.NodeClass = "chemtrail-default"
+		node.NodeClass = defaultNodeClass
 	}
 }
 
@@ -156,10 +188,39 @@ func (n *updateHandler) getNodeAllocatableResources(node *api.Node) *resources {
 	if node.NodeResources != nil && node.ReservedResources != nil {
 		r.cpu = float64(node.NodeResources.Cpu.CpuShares) - float64(node.ReservedResources.Cpu.CpuShares)
 		r.memory = float64(node.NodeResources.Memory.MemoryMB) - float64(node.ReservedResources.Memory.MemoryMB)
+		r.disk = float64(node.NodeResources.Disk.DiskMB) - float64(node.ReservedResources.Disk.DiskMB)
+		r.network = networkMBits(node.NodeResources.Networks)
+		r.devices = healthyDeviceInstances(node.NodeResources.Devices)
 	}
 	if node.Resources != nil && node.Reserved != nil {
 		r.cpu = float64(*node.Resources.CPU) - float64(*node.Reserved.CPU)
 		r.memory = float64(*node.Resources.MemoryMB) - float64(*node.Reserved.MemoryMB)
+
+		if node.Resources.DiskMB != nil {
+			r.disk = float64(*node.Resources.DiskMB)
+			if node.Reserved.DiskMB != nil {
+				r.disk -= float64(*node.Reserved.DiskMB)
+			}
+		}
+		r.network = networkMBits(node.Resources.Networks) - networkMBits(node.Reserved.Networks)
 	}
 	return &r
 }
+
+// healthyDeviceInstances counts the healthy device instances available on the node. Unhealthy
+// instances cannot be allocated so are not included.
+func healthyDeviceInstances(devices []*api.NodeDeviceResource) float64 {
+	var out float64
+
+	for _, device := range devices {
+		if device == nil {
+			continue
+		}
+		for _, instance := range device.Instances {
+			if instance != nil && instance.Healthy {
+				out++
+			}
+		}
+	}
+	return out
+}
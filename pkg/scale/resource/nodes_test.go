@@ -37,8 +37,9 @@ func Test_updateHandler_handleNodeAvailableMessage(t *testing.T) {
 					class: "test-class",
 					nodes: map[string]*nodeInfo{
 						"test-node": {
-							ID:    "test-node",
-							class: "test-class",
+							ID:          "test-node",
+							class:       "test-class",
+							allocations: make(map[string]*resources),
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 0, memory: 0},
@@ -105,8 +106,9 @@ func Test_updateHandler_handleNodeAvailableMessage(t *testing.T) {
 							},
 						},
 						"new-node": {
-							ID:    "new-node",
-							class: "test-class",
+							ID:          "new-node",
+							class:       "test-class",
+							allocations: make(map[string]*resources),
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 0, memory: 0},
@@ -183,8 +185,9 @@ func Test_updateHandler_handleNodeAvailableMessage(t *testing.T) {
 					class: "new-class",
 					nodes: map[string]*nodeInfo{
 						"new-node": {
-							ID:    "new-node",
-							class: "new-class",
+							ID:          "new-node",
+							class:       "new-class",
+							allocations: make(map[string]*resources),
 							resourceStats: &resourceStats{
 								allocatableResources: &resources{cpu: 5182, memory: 985},
 								allocatedResources:   &resources{cpu: 0, memory: 0},
@@ -433,6 +436,41 @@ func Test_updateHandler_getNodeAllocatableResources(t *testing.T) {
 			expectedOutput: &resources{cpu: 5182, memory: 985},
 			name:           "gh-26 older version of Nomad",
 		},
+		{
+			inputNode: &api.Node{
+				NodeResources: &api.NodeResources{
+					Cpu:      api.NodeCpuResources{CpuShares: 5182},
+					Memory:   api.NodeMemoryResources{MemoryMB: 985},
+					Disk:     api.NodeDiskResources{DiskMB: 20000},
+					Networks: []*api.NetworkResource{{MBits: intToPointer(1000)}},
+					Devices: []*api.NodeDeviceResource{
+						{Instances: []*api.NodeDevice{{ID: "gpu-1", Healthy: true}, {ID: "gpu-2", Healthy: false}}},
+					},
+				},
+				ReservedResources: &api.NodeReservedResources{
+					Disk: api.NodeReservedDiskResources{DiskMB: 500},
+				},
+			},
+			expectedOutput: &resources{cpu: 5182, memory: 985, disk: 19500, network: 1000, devices: 1},
+			name:           "disk, network and device resources",
+		},
+		{
+			inputNode: &api.Node{
+				Resources: &api.Resources{
+					CPU:      intToPointer(5182),
+					MemoryMB: intToPointer(985),
+					DiskMB:   intToPointer(20000),
+					Networks: []*api.NetworkResource{{MBits: intToPointer(1000)}},
+				},
+				Reserved: &api.Resources{
+					CPU:      intToPointer(0),
+					MemoryMB: intToPointer(0),
+					DiskMB:   intToPointer(500),
+				},
+			},
+			expectedOutput: &resources{cpu: 5182, memory: 985, disk: 19500, network: 1000},
+			name:           "gh-26 older version of Nomad disk and network resources",
+		},
 	}
 	uh := &updateHandler{}
 
@@ -441,3 +479,53 @@ func Test_updateHandler_getNodeAllocatableResources(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
 	}
 }
+
+func Test_isNodeProtected(t *testing.T) {
+	testCases := []struct {
+		inputNode      *api.Node
+		expectedOutput bool
+		name           string
+	}{
+		{
+			inputNode:      &api.Node{},
+			expectedOutput: false,
+			name:           "node without meta",
+		},
+		{
+			inputNode:      &api.Node{Meta: map[string]string{"chemtrail.protected": "true"}},
+			expectedOutput: true,
+			name:           "protected node",
+		},
+		{
+			inputNode:      &api.Node{Meta: map[string]string{"chemtrail.protected": "false"}},
+			expectedOutput: false,
+			name:           "unprotected node",
+		},
+		{
+			inputNode:      &api.Node{Meta: map[string]string{"chemtrail.protected": "yes please"}},
+			expectedOutput: false,
+			name:           "unparsable meta value",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, isNodeProtected(tc.inputNode), tc.name)
+	}
+}
+
+func Test_updateHandler_handleNodeAvailableMessageProtection(t *testing.T) {
+	h := &updateHandler{
+		nodePool:  make(map[string]*classInfo),
+		nodeClass: make(map[string]string),
+	}
+	node := &api.Node{ID: "test-node", NodeClass: "test-class", Status: "ready"}
+
+	h.handleNodeAvailableMessage(node)
+	assert.False(t, h.nodePool["test-class"].nodes["test-node"].protected)
+
+	// Updating the node meta does not change the node status, but the protection must still be
+	// refreshed.
+	node.Meta = map[string]string{"chemtrail.protected": "true"}
+	h.handleNodeAvailableMessage(node)
+	assert.True(t, h.nodePool["test-class"].nodes["test-node"].protected)
+}
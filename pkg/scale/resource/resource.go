@@ -2,9 +2,14 @@ package resource
 
 import (
 	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/jrasell/chemtrail/pkg/client"
-
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -21,9 +26,27 @@ type classInfo struct {
 type nodeInfo struct {
 	ID            string
 	class         string
+	createIndex   uint64
+	attributes    map[string]string
+	protected     bool
 	status        string
 	eligibility   string
 	resourceStats *resourceStats
+
+	// allocations tracks the resources of each running allocation on the node, keyed by the
+	// allocation ID. This allows placement of the allocations elsewhere to be checked.
+	allocations map[string]*resources
+
+	// jobs tracks the job of each running allocation on the node, keyed by the allocation ID.
+	// This allows nodes running protected jobs to be excluded from scaling in.
+	jobs map[string]*allocJob
+}
+
+// allocJob details the job of a running allocation.
+type allocJob struct {
+	id      string
+	jobType string
+	meta    map[string]string
 }
 
 // resourceStats represents the currently tracked CPU and memory stats for the component. This is
@@ -32,12 +55,20 @@ type nodeInfo struct {
 type resourceStats struct {
 	allocatableResources *resources
 	allocatedResources   *resources
+
+	// usedResources is the actual resource usage of the running allocations as reported by the
+	// Nomad clients. This is nil until the usage collector has run.
+	usedResources *resources
 }
 
-// resources is the basic tracked resources which Chemtrail can scale on.
+// resources is the basic tracked resources which Chemtrail can scale on. Disk is measured in MB,
+// network in MBits, and devices as a count of device instances.
 type resources struct {
-	cpu    float64
-	memory float64
+	cpu     float64
+	memory  float64
+	disk    float64
+	network float64
+	devices float64
 }
 
 // AllocatedStats is used to return information about the current allocated resources within a
@@ -51,55 +82,201 @@ type AllocatedStats struct {
 	// Memory is the currently allocated memory as a percentage of the overall allocatable memory
 	// resource within a class.
 	Memory float64
+
+	// Disk is the currently allocated disk as a percentage of the overall allocatable disk
+	// resource within a class.
+	Disk float64
+
+	// Network is the currently allocated network bandwidth as a percentage of the overall
+	// allocatable network bandwidth within a class.
+	Network float64
+
+	// Devices is the currently allocated device instances as a percentage of the overall
+	// allocatable device instances within a class.
+	Devices float64
+
+	// QueuedAllocs is the number of allocations which Nomad has been unable to place and which
+	// are attributed to the class. Unlike the other stats, this is an absolute count rather than
+	// a percentage.
+	QueuedAllocs float64
+
+	// CPUUsed is the CPU actually used by the running allocations as a percentage of the overall
+	// allocatable CPU resource within a class.
+	CPUUsed float64
+
+	// MemoryUsed is the memory actually used by the running allocations as a percentage of the
+	// overall allocatable memory resource within a class.
+	MemoryUsed float64
+
+	// UsageCollected indicates whether the used resource stats have been collected. If false, the
+	// CPUUsed and MemoryUsed values should not be relied upon.
+	UsageCollected bool
+}
+
+// HandlerConfig is the configuration used to build a new resource Handler.
+type HandlerConfig struct {
+	Logger zerolog.Logger
+	Nomad  *client.Nomad
+
+	// SampleInterval is the time period in seconds between resource samples being taken of each
+	// class.
+	SampleInterval int
+
+	// SampleRetention is the time period in seconds for which resource samples are retained. This
+	// should be greater than or equal to the largest check aggregation window.
+	SampleRetention int
+
+	// UsageInterval is the time period in seconds between the actual resource usage of the
+	// running allocations being collected from the Nomad clients.
+	UsageInterval int
 }
 
 type handler struct {
 	logger      zerolog.Logger
 	nodeManager *updateHandler
+
+	// samples holds the ring buffer of resource samples for each class, keyed by the class name.
+	samples         map[string]*sampleBuffer
+	samplesLock     sync.RWMutex
+	sampleInterval  int
+	sampleRetention int
+	usageInterval   int
+
+	// rand is the random source used by the random scale in node selector.
+	rand     *rand.Rand
+	randLock sync.Mutex
 }
 
-// GetLeastAllocatedNodeInClass satisfies the GetLeastAllocatedNodeInClass function on the Handler
-// interface.
-func (h *handler) GetLeastAllocatedNodeInClass(class string) *nodeInfo {
-	classInfo, ok := h.nodeManager.nodePool[class]
-	if !ok {
-		return nil
+// SelectScaleInNodes satisfies the SelectScaleInNodes function on the Handler interface.
+func (h *handler) SelectScaleInNodes(class string, selector *state.ScaleInSelector, protection *state.ScaleInProtection, count int) []*nodeInfo {
+	h.nodeManager.nodePoolLock.RLock()
+	defer h.nodeManager.nodePoolLock.RUnlock()
+
+	nodes := h.removableNodes(class, protection)
+
+	// Sort the candidates so that selectors break ties consistently, rather than depending on the
+	// map iteration order.
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	nodeSelector := newNodeSelector(selector, h.calculateAllocatedPercentageStats, h.randIntn)
+
+	// Nodes are selected one at a time, removing each selected node from the candidates. This
+	// allows strategies such as az-balanced to account for the nodes already selected.
+	var selected []*nodeInfo
+
+	for len(selected) < count {
+		node := nodeSelector.selectNode(nodes)
+		if node == nil {
+			break
+		}
+		selected = append(selected, node)
+		nodes = removeNodeInfo(nodes, node)
 	}
+	return selected
+}
 
-	var (
-		lowestPercentage float64
-		lowestNode       *nodeInfo
-	)
+// removeNodeInfo returns the nodes without the passed node, retaining the order of the remaining
+// nodes.
+func removeNodeInfo(nodes []*nodeInfo, remove *nodeInfo) []*nodeInfo {
+	out := make([]*nodeInfo, 0, len(nodes))
+	for _, node := range nodes {
+		if node != remove {
+			out = append(out, node)
+		}
+	}
+	return out
+}
 
-	for _, node := range classInfo.nodes {
+// GetRemovableNodeCount satisfies the GetRemovableNodeCount function on the Handler interface.
+func (h *handler) GetRemovableNodeCount(class string, protection *state.ScaleInProtection) int {
+	h.nodeManager.nodePoolLock.RLock()
+	defer h.nodeManager.nodePoolLock.RUnlock()
+	return len(h.removableNodes(class, protection))
+}
 
-		// In its current form, we need to protect the node Chemtrail is running on from scaling.
-		// This will change in the future when HA features come in, but it easier now to just skip
-		// the assessment of the Chemtrail node.
-		if node.ID == h.nodeManager.nomad.NodeID {
+// GetNodes satisfies the GetNodes function on the Handler interface.
+func (h *handler) GetNodes(class string) []*state.NodeStatus {
+	h.nodeManager.nodePoolLock.RLock()
+	defer h.nodeManager.nodePoolLock.RUnlock()
+
+	out := []*state.NodeStatus{}
+
+	for name, classInfo := range h.nodeManager.nodePool {
+		if class != "" && name != class {
 			continue
 		}
+		for _, node := range classInfo.nodes {
+			out = append(out, &state.NodeStatus{
+				ID:          node.ID,
+				Class:       node.class,
+				Status:      node.status,
+				Eligibility: node.eligibility,
+				Allocations: len(node.allocations),
+				Protected:   node.protected,
+			})
+		}
+	}
 
-		stats := h.calculateAllocatedPercentageStats(node.resourceStats)
-
-		// We need to set an initial benchmark for comparison.
-		if lowestNode == nil && lowestPercentage == 0 {
-			lowestPercentage = stats.Memory
-			lowestNode = node
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Class != out[j].Class {
+			return out[i].Class < out[j].Class
 		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// removableNodes returns the nodes of the class which can be removed during a scale in activity.
+// The caller must hold the node pool lock.
+//
+// Nodes protected via their meta, or running allocations of jobs matched by the active job
+// protection, cannot be removed. The node the Chemtrail leader is running on is also protected,
+// as draining it would abort the scale in activity part way through and force a leadership
+// change, even when other servers are available to take over.
+func (h *handler) removableNodes(class string, protection *state.ScaleInProtection) []*nodeInfo {
+	classInfo, ok := h.nodeManager.nodePool[class]
+	if !ok {
+		return nil
+	}
 
-		if stats.Memory < lowestPercentage {
-			lowestPercentage = stats.Memory
-			lowestNode = node
+	// If the protection window cannot be calculated, the protection is applied as removing a
+	// protected node is more disruptive than failing to scale in.
+	active, err := protection.IsActive(time.Now())
+	if err != nil {
+		h.logger.Error().Err(err).Str("node-class", class).Msg("failed to check scale in protection window")
+		active = true
+	}
+
+	nodes := make([]*nodeInfo, 0, len(classInfo.nodes))
+	for _, node := range classInfo.nodes {
+		if node.protected || node.ID == h.nodeManager.nomad.NodeID {
+			continue
+		}
+		if active && node.runsProtectedJob(protection) {
+			continue
 		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
 
-		if stats.CPU < lowestPercentage {
-			lowestPercentage = stats.Memory
-			lowestNode = node
+// runsProtectedJob identifies whether the node is running an allocation of a job matched by the
+// protection.
+func (n *nodeInfo) runsProtectedJob(protection *state.ScaleInProtection) bool {
+	for _, job := range n.jobs {
+		if protection.MatchesJob(job.id, job.jobType, job.meta) {
+			return true
 		}
 	}
+	return false
+}
 
-	return lowestNode
+// randIntn returns a pseudo-random number in the range [0,n) from the handler random source,
+// which is not safe for concurrent use on its own.
+func (h *handler) randIntn(n int) int {
+	h.randLock.Lock()
+	defer h.randLock.Unlock()
+	return h.rand.Intn(n)
 }
 
 // GetClassResourceAllocation satisfies the GetClassResourceAllocation function on the Handler interface.
@@ -112,12 +289,46 @@ func (h *handler) GetClassResourceAllocation(class string) (*AllocatedStats, err
 	if !ok {
 		return nil, errors.New("no nodes of class found")
 	}
-	return h.calculateAllocatedPercentageStats(nodes.resourceStats), nil
+
+	stats := h.calculateAllocatedPercentageStats(nodes.resourceStats)
+	stats.QueuedAllocs = float64(h.nodeManager.getClassQueuedAllocs(class))
+	return stats, nil
+}
+
+// GetAggregatedClassResourceAllocation satisfies the GetAggregatedClassResourceAllocation function
+// on the Handler interface.
+func (h *handler) GetAggregatedClassResourceAllocation(class string, window time.Duration, fn state.AggregationFunction) (*AllocatedStats, error) {
+	h.samplesLock.RLock()
+	buf, ok := h.samples[class]
+	var samples []sample
+	if ok {
+		samples = buf.since(helper.GenerateEventTimestamp() - window.Nanoseconds())
+	}
+	h.samplesLock.RUnlock()
+
+	// If no samples have yet been taken within the window, such as just after startup, the
+	// current allocation is used.
+	if len(samples) == 0 {
+		return h.GetClassResourceAllocation(class)
+	}
+	return aggregateSamples(samples, fn), nil
 }
 
+// RunResourceSampler satisfies the RunResourceSampler function on the Handler interface.
+func (h *handler) RunResourceSampler() { go h.runResourceSampler() }
+
+// RunUsageCollector satisfies the RunUsageCollector function on the Handler interface.
+func (h *handler) RunUsageCollector() { go h.runUsageCollector() }
+
 // StopUpdateHandlers satisfies the StopUpdateHandlers function on the Handler interface.
 func (h *handler) StopUpdateHandlers() { close(h.nodeManager.shutdownChan) }
 
+// GetEvalUpdateChan satisfies the GetEvalUpdateChan function on the Handler interface.
+func (h *handler) GetEvalUpdateChan() chan interface{} { return h.nodeManager.evalUpdateChan }
+
+// RunEvalUpdateHandler satisfies the RunEvalUpdateHandler function on the Handler interface.
+func (h *handler) RunEvalUpdateHandler() { go h.nodeManager.runEvalUpdateHandler() }
+
 // GetAllocUpdateChan satisfies the GetAllocUpdateChan function on the Handler interface.
 func (h *handler) GetAllocUpdateChan() chan interface{} { return h.nodeManager.allocUpdateChan }
 
@@ -140,16 +351,24 @@ func (h *handler) GetNodesOfClass(class string) map[string]*nodeInfo {
 
 // NewHandler creates a new resource handler for interactions with the resource state stored within
 // Chemtrail based off updates from Nomad via watchers.
-func NewHandler(logger zerolog.Logger, nomad *client.Nomad) Handler {
+func NewHandler(cfg *HandlerConfig) Handler {
 	return &handler{
-		logger: logger,
+		logger:          cfg.Logger,
+		samples:         make(map[string]*sampleBuffer),
+		sampleInterval:  cfg.SampleInterval,
+		sampleRetention: cfg.SampleRetention,
+		usageInterval:   cfg.UsageInterval,
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())),
 		nodeManager: &updateHandler{
-			logger:          logger,
-			nomad:           nomad,
+			logger:          cfg.Logger,
+			nomad:           cfg.Nomad,
 			nodePool:        make(map[string]*classInfo),
 			nodeClass:       make(map[string]string),
 			nodeUpdateChan:  make(chan interface{}),
 			allocUpdateChan: make(chan interface{}),
+			evalUpdateChan:  make(chan interface{}),
+			blockedEvals:    make(map[string][]*queuedAsk),
+			joinWatchers:    make(map[*joinWatcher]struct{}),
 			shutdownChan:    make(chan struct{}),
 		},
 	}
@@ -158,11 +377,48 @@ func NewHandler(logger zerolog.Logger, nomad *client.Nomad) Handler {
 // calculateAllocatedPercentageStats is used to calculate the percentage of resources allocated out
 // of the total allocatable resources.
 func (h *handler) calculateAllocatedPercentageStats(stats *resourceStats) *AllocatedStats {
-	cpu := (stats.allocatedResources.cpu * float64(100)) / stats.allocatableResources.cpu
-	mem := (stats.allocatedResources.memory * float64(100)) / stats.allocatableResources.memory
+	allocated, allocatable := stats.allocatedResources, stats.allocatableResources
+
+	out := AllocatedStats{
+		CPU:     percentage(allocated.cpu, allocatable.cpu),
+		Memory:  percentage(allocated.memory, allocatable.memory),
+		Disk:    percentage(allocated.disk, allocatable.disk),
+		Network: percentage(allocated.network, allocatable.network),
+		Devices: percentage(allocated.devices, allocatable.devices),
+	}
+
+	if stats.usedResources != nil {
+		out.CPUUsed = percentage(stats.usedResources.cpu, allocatable.cpu)
+		out.MemoryUsed = percentage(stats.usedResources.memory, allocatable.memory)
+		out.UsageCollected = true
+	}
+	return &out
+}
 
-	return &AllocatedStats{
-		CPU:    math.Round(cpu),
-		Memory: math.Round(mem),
+// percentage calculates the rounded percentage of the total which is used. Resources such as
+// devices are not present on all nodes, therefore a total of 0 returns 0 rather than dividing by
+// zero.
+func percentage(used, total float64) float64 {
+	if total == 0 {
+		return 0
 	}
+	return math.Round((used * float64(100)) / total)
+}
+
+// add increments the resources by the passed resources.
+func (r *resources) add(other *resources) {
+	r.cpu += other.cpu
+	r.memory += other.memory
+	r.disk += other.disk
+	r.network += other.network
+	r.devices += other.devices
+}
+
+// sub decrements the resources by the passed resources.
+func (r *resources) sub(other *resources) {
+	r.cpu -= other.cpu
+	r.memory -= other.memory
+	r.disk -= other.disk
+	r.network -= other.network
+	r.devices -= other.devices
 }
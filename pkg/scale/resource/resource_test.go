@@ -1,8 +1,11 @@
 package resource
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/jrasell/chemtrail/pkg/client"
+	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,6 +41,23 @@ func Test_handler_calculateAllocatedPercentageStats(t *testing.T) {
 			expectedOutput: &AllocatedStats{CPU: 10, Memory: 10},
 			name:           "10% allocated memory and cpu",
 		},
+		{
+			inputStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000},
+				allocatedResources:   &resources{cpu: 500, memory: 800},
+				usedResources:        &resources{cpu: 100, memory: 250},
+			},
+			expectedOutput: &AllocatedStats{CPU: 50, Memory: 80, CPUUsed: 10, MemoryUsed: 25, UsageCollected: true},
+			name:           "collected usage",
+		},
+		{
+			inputStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000, disk: 10000, network: 1000},
+				allocatedResources:   &resources{cpu: 100, memory: 100, disk: 7500, network: 200},
+			},
+			expectedOutput: &AllocatedStats{CPU: 10, Memory: 10, Disk: 75, Network: 20, Devices: 0},
+			name:           "disk and network with no allocatable devices",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -45,3 +65,195 @@ func Test_handler_calculateAllocatedPercentageStats(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
 	}
 }
+
+func Test_handler_removableNodes(t *testing.T) {
+	newNode := func(id string, protected bool, jobs ...*allocJob) *nodeInfo {
+		node := &nodeInfo{
+			ID:          id,
+			class:       "test-class",
+			status:      "ready",
+			eligibility: "eligible",
+			protected:   protected,
+			allocations: map[string]*resources{},
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000},
+				allocatedResources:   &resources{},
+			},
+			jobs: map[string]*allocJob{},
+		}
+		for i, job := range jobs {
+			node.jobs[fmt.Sprintf("%s-alloc-%v", id, i)] = job
+		}
+		return node
+	}
+
+	stateful := &allocJob{id: "stateful", jobType: "service", meta: map[string]string{"team": "data"}}
+	web := &allocJob{id: "web", jobType: "service"}
+
+	testCases := []struct {
+		inputNodes             []*nodeInfo
+		inputProtection        *state.ScaleInProtection
+		expectedSelectedNode   string
+		expectedRemovableCount int
+		name                   string
+	}{
+		{
+			inputNodes:             []*nodeInfo{newNode("node-a", false), newNode("node-b", false)},
+			expectedSelectedNode:   "node-a",
+			expectedRemovableCount: 2,
+			name:                   "no protected nodes",
+		},
+		{
+			inputNodes:             []*nodeInfo{newNode("node-a", true), newNode("node-b", false)},
+			expectedSelectedNode:   "node-b",
+			expectedRemovableCount: 1,
+			name:                   "protected node not selected",
+		},
+		{
+			inputNodes:             []*nodeInfo{newNode("chemtrail-node", false), newNode("node-b", true)},
+			expectedSelectedNode:   "",
+			expectedRemovableCount: 0,
+			name:                   "chemtrail node and protected node not selected",
+		},
+		{
+			inputNodes:             []*nodeInfo{newNode("node-a", false, stateful, web), newNode("node-b", false, web)},
+			inputProtection:        &state.ScaleInProtection{JobIDs: []string{"stateful"}},
+			expectedSelectedNode:   "node-b",
+			expectedRemovableCount: 1,
+			name:                   "node running protected job ID not selected",
+		},
+		{
+			inputNodes:             []*nodeInfo{newNode("node-a", false, stateful), newNode("node-b", false, web)},
+			inputProtection:        &state.ScaleInProtection{JobMeta: map[string]string{"team": "data"}},
+			expectedSelectedNode:   "node-b",
+			expectedRemovableCount: 1,
+			name:                   "node running protected job meta not selected",
+		},
+		{
+			inputNodes:             []*nodeInfo{newNode("node-a", false, stateful), newNode("node-b", false, web)},
+			inputProtection:        &state.ScaleInProtection{JobTypes: []string{"service"}},
+			expectedSelectedNode:   "",
+			expectedRemovableCount: 0,
+			name:                   "all nodes running protected job type",
+		},
+		{
+			inputNodes: []*nodeInfo{newNode("node-a", false, stateful), newNode("node-b", false, web)},
+			inputProtection: &state.ScaleInProtection{
+				JobIDs: []string{"stateful"},
+				Window: &state.ProtectionWindow{Cron: "0 0 1 1 *", Duration: 1},
+			},
+			expectedSelectedNode:   "node-a",
+			expectedRemovableCount: 2,
+			name:                   "protection outside of window",
+		},
+	}
+
+	for _, tc := range testCases {
+		nodes := make(map[string]*nodeInfo)
+		for _, node := range tc.inputNodes {
+			nodes[node.ID] = node
+		}
+
+		h := &handler{nodeManager: &updateHandler{
+			nomad:    &client.Nomad{NodeID: "chemtrail-node"},
+			nodePool: map[string]*classInfo{"test-class": {class: "test-class", nodes: nodes}},
+		}}
+
+		var selected string
+		if nodes := h.SelectScaleInNodes("test-class", nil, tc.inputProtection, 1); len(nodes) == 1 {
+			selected = nodes[0].ID
+		}
+		assert.Equal(t, tc.expectedSelectedNode, selected, tc.name)
+		assert.Equal(t, tc.expectedRemovableCount, h.GetRemovableNodeCount("test-class", tc.inputProtection), tc.name)
+	}
+}
+
+func Test_handler_SelectScaleInNodes(t *testing.T) {
+	newNode := func(id, zone string, allocated float64) *nodeInfo {
+		return &nodeInfo{
+			ID:          id,
+			class:       "test-class",
+			status:      "ready",
+			eligibility: "eligible",
+			attributes:  map[string]string{state.DefaultAZAttribute: zone},
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000},
+				allocatedResources:   &resources{cpu: allocated, memory: allocated},
+			},
+		}
+	}
+
+	nodes := map[string]*nodeInfo{}
+	for _, node := range []*nodeInfo{
+		newNode("node-a", "zone-a", 100),
+		newNode("node-b", "zone-a", 200),
+		newNode("node-c", "zone-a", 300),
+		newNode("node-d", "zone-b", 50),
+		newNode("node-e", "zone-b", 400),
+	} {
+		nodes[node.ID] = node
+	}
+
+	h := &handler{nodeManager: &updateHandler{
+		nomad:    &client.Nomad{NodeID: "chemtrail-node"},
+		nodePool: map[string]*classInfo{"test-class": {class: "test-class", nodes: nodes}},
+	}}
+
+	testCases := []struct {
+		inputSelector  *state.ScaleInSelector
+		inputCount     int
+		expectedOutput []string
+		name           string
+	}{
+		{
+			inputSelector:  nil,
+			inputCount:     3,
+			expectedOutput: []string{"node-d", "node-a", "node-b"},
+			name:           "least allocated nodes",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Strategy: state.NodeSelectorAZBalanced},
+			inputCount:     3,
+			expectedOutput: []string{"node-a", "node-b", "node-d"},
+			name:           "az balanced accounts for selected nodes",
+		},
+		{
+			inputSelector:  nil,
+			inputCount:     10,
+			expectedOutput: []string{"node-d", "node-a", "node-b", "node-c", "node-e"},
+			name:           "count larger than removable nodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		var actualOutput []string
+		for _, node := range h.SelectScaleInNodes("test-class", tc.inputSelector, nil, tc.inputCount) {
+			actualOutput = append(actualOutput, node.ID)
+		}
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_handler_GetNodes(t *testing.T) {
+	h := &handler{nodeManager: &updateHandler{
+		nodePool: map[string]*classInfo{
+			"class-b": {nodes: map[string]*nodeInfo{
+				"node-c": {ID: "node-c", class: "class-b", status: "ready", protected: true},
+			}},
+			"class-a": {nodes: map[string]*nodeInfo{
+				"node-b": {ID: "node-b", class: "class-a", status: "ready"},
+				"node-a": {ID: "node-a", class: "class-a", status: "ready",
+					allocations: map[string]*resources{"alloc-1": {}}},
+			}},
+		},
+	}}
+
+	expectedAll := []*state.NodeStatus{
+		{ID: "node-a", Class: "class-a", Status: "ready", Allocations: 1},
+		{ID: "node-b", Class: "class-a", Status: "ready"},
+		{ID: "node-c", Class: "class-b", Status: "ready", Protected: true},
+	}
+	assert.Equal(t, expectedAll, h.GetNodes(""))
+	assert.Equal(t, expectedAll[2:], h.GetNodes("class-b"))
+	assert.Equal(t, []*state.NodeStatus{}, h.GetNodes("class-c"))
+}
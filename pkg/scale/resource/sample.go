@@ -0,0 +1,130 @@
+package resource
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// sample is a point in time snapshot of the allocated resource stats of a class.
+type sample struct {
+	timestamp int64
+	stats     AllocatedStats
+}
+
+// sampleBuffer is a fixed size ring buffer of class samples. Once full, new samples overwrite the
+// oldest held.
+type sampleBuffer struct {
+	samples []sample
+	next    int
+	full    bool
+}
+
+// newSampleBuffer creates a new sampleBuffer which can hold the specified number of samples.
+func newSampleBuffer(size int) *sampleBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &sampleBuffer{samples: make([]sample, size)}
+}
+
+// add writes the sample to the buffer, overwriting the oldest sample if the buffer is full.
+func (b *sampleBuffer) add(s sample) {
+	b.samples[b.next] = s
+	b.next = (b.next + 1) % len(b.samples)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// since returns all the samples held within the buffer which have a timestamp greater than or
+// equal to the passed UnixNano timestamp. The samples are returned oldest to newest.
+func (b *sampleBuffer) since(ts int64) []sample {
+	var (
+		out   []sample
+		start int
+		count = b.next
+	)
+
+	if b.full {
+		start = b.next
+		count = len(b.samples)
+	}
+
+	for i := 0; i < count; i++ {
+		s := b.samples[(start+i)%len(b.samples)]
+		if s.timestamp >= ts {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// aggregateSamples reduces the passed samples into a single AllocatedStats using the aggregation
+// function. Each resource is aggregated independently.
+func aggregateSamples(samples []sample, fn state.AggregationFunction) *AllocatedStats {
+	cpu := make([]float64, len(samples))
+	mem := make([]float64, len(samples))
+	disk := make([]float64, len(samples))
+	network := make([]float64, len(samples))
+	devices := make([]float64, len(samples))
+	queued := make([]float64, len(samples))
+
+	// Usage stats are only aggregated from the samples where they were collected.
+	var cpuUsed, memUsed []float64
+
+	for i := range samples {
+		cpu[i] = samples[i].stats.CPU
+		mem[i] = samples[i].stats.Memory
+		disk[i] = samples[i].stats.Disk
+		network[i] = samples[i].stats.Network
+		devices[i] = samples[i].stats.Devices
+		queued[i] = samples[i].stats.QueuedAllocs
+
+		if samples[i].stats.UsageCollected {
+			cpuUsed = append(cpuUsed, samples[i].stats.CPUUsed)
+			memUsed = append(memUsed, samples[i].stats.MemoryUsed)
+		}
+	}
+
+	return &AllocatedStats{
+		CPU:            aggregate(cpu, fn),
+		Memory:         aggregate(mem, fn),
+		Disk:           aggregate(disk, fn),
+		Network:        aggregate(network, fn),
+		Devices:        aggregate(devices, fn),
+		QueuedAllocs:   aggregate(queued, fn),
+		CPUUsed:        aggregate(cpuUsed, fn),
+		MemoryUsed:     aggregate(memUsed, fn),
+		UsageCollected: len(cpuUsed) > 0,
+	}
+}
+
+// aggregate reduces the passed values using the aggregation function. If no values are passed, 0
+// is returned.
+func aggregate(values []float64, fn state.AggregationFunction) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	switch fn {
+	case state.AggregationMax:
+		return sorted[len(sorted)-1]
+	case state.AggregationMin:
+		return sorted[0]
+	case state.AggregationP95:
+		// Use the nearest-rank method to identify the percentile value.
+		rank := int(math.Ceil(0.95 * float64(len(sorted))))
+		return sorted[rank-1]
+	default:
+		var sum float64
+		for _, v := range sorted {
+			sum += v
+		}
+		return math.Round(sum / float64(len(sorted)))
+	}
+}
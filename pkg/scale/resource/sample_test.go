@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sampleBuffer(t *testing.T) {
+	buf := newSampleBuffer(3)
+	assert.Nil(t, buf.since(0), "empty buffer")
+
+	buf.add(sample{timestamp: 1, stats: AllocatedStats{CPU: 10}})
+	buf.add(sample{timestamp: 2, stats: AllocatedStats{CPU: 20}})
+	assert.Equal(t, []sample{
+		{timestamp: 1, stats: AllocatedStats{CPU: 10}},
+		{timestamp: 2, stats: AllocatedStats{CPU: 20}},
+	}, buf.since(0), "partially filled buffer")
+
+	buf.add(sample{timestamp: 3, stats: AllocatedStats{CPU: 30}})
+	buf.add(sample{timestamp: 4, stats: AllocatedStats{CPU: 40}})
+	assert.Equal(t, []sample{
+		{timestamp: 2, stats: AllocatedStats{CPU: 20}},
+		{timestamp: 3, stats: AllocatedStats{CPU: 30}},
+		{timestamp: 4, stats: AllocatedStats{CPU: 40}},
+	}, buf.since(0), "wrapped buffer overwrites oldest sample")
+
+	assert.Equal(t, []sample{
+		{timestamp: 3, stats: AllocatedStats{CPU: 30}},
+		{timestamp: 4, stats: AllocatedStats{CPU: 40}},
+	}, buf.since(3), "samples filtered by timestamp")
+}
+
+func Test_aggregate(t *testing.T) {
+	values := []float64{10, 50, 20, 90, 30, 40, 60, 70, 80, 100, 15, 25, 35, 45, 55, 65, 75, 85, 95, 5}
+
+	testCases := []struct {
+		inputValues    []float64
+		inputFunction  state.AggregationFunction
+		expectedOutput float64
+		name           string
+	}{
+		{
+			inputValues:    nil,
+			inputFunction:  state.AggregationAverage,
+			expectedOutput: 0,
+			name:           "no values",
+		},
+		{
+			inputValues:    []float64{10, 20, 60},
+			inputFunction:  state.AggregationAverage,
+			expectedOutput: 30,
+			name:           "average",
+		},
+		{
+			inputValues:    values,
+			inputFunction:  state.AggregationMax,
+			expectedOutput: 100,
+			name:           "max",
+		},
+		{
+			inputValues:    values,
+			inputFunction:  state.AggregationMin,
+			expectedOutput: 5,
+			name:           "min",
+		},
+		{
+			inputValues:    values,
+			inputFunction:  state.AggregationP95,
+			expectedOutput: 95,
+			name:           "95th percentile",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := aggregate(tc.inputValues, tc.inputFunction)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
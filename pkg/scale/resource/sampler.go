@@ -0,0 +1,74 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/jrasell/chemtrail/pkg/helper"
+)
+
+// runResourceSampler periodically snapshots the allocated resource stats of every tracked class
+// and stores them within the class sample buffer. This allows checks to aggregate resource values
+// over a time window rather than relying on a single instantaneous value.
+func (h *handler) runResourceSampler() {
+	h.logger.Info().
+		Int("sample-interval", h.sampleInterval).
+		Int("sample-retention", h.sampleRetention).
+		Msg("starting Chemtrail resource sampler")
+
+	t := time.NewTicker(time.Duration(h.sampleInterval) * time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-h.nodeManager.shutdownChan:
+			h.logger.Info().Msg("shutting down Chemtrail resource sampler")
+			return
+		case <-t.C:
+			h.sampleClasses()
+		}
+	}
+}
+
+// sampleClasses takes a snapshot of the allocated resource stats of each class.
+func (h *handler) sampleClasses() {
+	ts := helper.GenerateEventTimestamp()
+
+	h.nodeManager.nodePoolLock.RLock()
+	snapshot := make(map[string]*AllocatedStats, len(h.nodeManager.nodePool))
+	for class, info := range h.nodeManager.nodePool {
+		snapshot[class] = h.calculateAllocatedPercentageStats(info.resourceStats)
+	}
+	h.nodeManager.nodePoolLock.RUnlock()
+
+	for class, stats := range snapshot {
+		stats.QueuedAllocs = float64(h.nodeManager.getClassQueuedAllocs(class))
+	}
+
+	h.samplesLock.Lock()
+	defer h.samplesLock.Unlock()
+
+	for class, stats := range snapshot {
+		buf, ok := h.samples[class]
+		if !ok {
+			buf = newSampleBuffer(h.sampleBufferSize())
+			h.samples[class] = buf
+		}
+		buf.add(sample{timestamp: ts, stats: *stats})
+	}
+
+	// Remove the buffers of classes which are no longer tracked.
+	for class := range h.samples {
+		if _, ok := snapshot[class]; !ok {
+			delete(h.samples, class)
+		}
+	}
+}
+
+// sampleBufferSize calculates the number of samples to hold per class based on the configured
+// retention and interval.
+func (h *handler) sampleBufferSize() int {
+	if h.sampleInterval < 1 {
+		return 1
+	}
+	return h.sampleRetention / h.sampleInterval
+}
@@ -0,0 +1,207 @@
+package resource
+
+import (
+	"sort"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// nodeSelector is the interface which scale in node selection strategies must implement. The
+// selector is passed the candidate nodes of the class, sorted by ID, and returns the node which
+// should be removed, or nil if no node can be selected.
+type nodeSelector interface {
+	selectNode(nodes []*nodeInfo) *nodeInfo
+}
+
+// statsFunc calculates the allocated percentage stats of the passed resource stats.
+type statsFunc func(stats *resourceStats) *AllocatedStats
+
+// newNodeSelector builds the nodeSelector for the strategy of the passed selector config. A nil
+// config returns the default least-allocated selector. The stats function is used to calculate the
+// allocation of each node, and intn to pick a random node index in the range [0,n).
+func newNodeSelector(cfg *state.ScaleInSelector, stats statsFunc, intn func(n int) int) nodeSelector {
+	leastAllocated := &leastAllocatedSelector{weights: cfg.GetWeights(), stats: stats}
+
+	switch cfg.GetStrategy() {
+	case state.NodeSelectorOldest:
+		return &registrationSelector{newest: false}
+	case state.NodeSelectorNewest:
+		return &registrationSelector{newest: true}
+	case state.NodeSelectorFewestAllocs:
+		return &fewestAllocsSelector{}
+	case state.NodeSelectorAZBalanced:
+		return &azBalancedSelector{attribute: cfg.GetAttribute(), leastAllocated: leastAllocated}
+	case state.NodeSelectorRandom:
+		return &randomSelector{intn: intn}
+	default:
+		return leastAllocated
+	}
+}
+
+// leastAllocatedSelector selects the node with the lowest allocation score. The score is the
+// weighted mean of the allocated percentage of each weighted resource. Nodes whose usage has not
+// been collected are only selected if no node with collected usage is available, so that a node
+// is not removed first because its usage is unknown.
+type leastAllocatedSelector struct {
+	weights map[state.ScaleResource]float64
+	stats   statsFunc
+}
+
+func (s *leastAllocatedSelector) selectNode(nodes []*nodeInfo) *nodeInfo {
+	var (
+		lowestScore    float64
+		lowestComplete bool
+		lowestNode     *nodeInfo
+	)
+
+	for _, node := range nodes {
+		score, complete := s.score(node)
+
+		switch {
+		case lowestNode == nil,
+			complete && !lowestComplete,
+			complete == lowestComplete && score < lowestScore:
+			lowestScore = score
+			lowestComplete = complete
+			lowestNode = node
+		}
+	}
+	return lowestNode
+}
+
+// score calculates the weighted mean allocated percentage of the node. If a weighted usage
+// resource has not been collected for the node, the allocated percentage of the equivalent
+// resource is used instead and the score is reported as incomplete.
+func (s *leastAllocatedSelector) score(node *nodeInfo) (float64, bool) {
+	stats := s.stats(node.resourceStats)
+
+	var total, weights float64
+	complete := true
+
+	for resource, weight := range s.weights {
+		if resource.IsUsage() && !stats.UsageCollected {
+			resource = allocatedEquivalent(resource)
+			complete = false
+		}
+		total += stats.value(resource) * weight
+		weights += weight
+	}
+
+	if weights == 0 {
+		return 0, complete
+	}
+	return total / weights, complete
+}
+
+// allocatedEquivalent returns the allocated resource which is equivalent to the usage resource.
+func allocatedEquivalent(r state.ScaleResource) state.ScaleResource {
+	switch r {
+	case state.ScaleResourceCPUUsed:
+		return state.ScaleResourceCPU
+	case state.ScaleResourceMemoryUsed:
+		return state.ScaleResourceMemory
+	default:
+		return r
+	}
+}
+
+// registrationSelector selects the node which registered with the Nomad cluster first, or most
+// recently if newest is set. The node registration order is identified using the Nomad create
+// index of the node.
+type registrationSelector struct {
+	newest bool
+}
+
+func (s *registrationSelector) selectNode(nodes []*nodeInfo) *nodeInfo {
+	var selected *nodeInfo
+
+	for _, node := range nodes {
+		switch {
+		case selected == nil,
+			s.newest && node.createIndex > selected.createIndex,
+			!s.newest && node.createIndex < selected.createIndex:
+			selected = node
+		}
+	}
+	return selected
+}
+
+// fewestAllocsSelector selects the node running the fewest allocations.
+type fewestAllocsSelector struct{}
+
+func (s *fewestAllocsSelector) selectNode(nodes []*nodeInfo) *nodeInfo {
+	var selected *nodeInfo
+
+	for _, node := range nodes {
+		if selected == nil || len(node.allocations) < len(selected.allocations) {
+			selected = node
+		}
+	}
+	return selected
+}
+
+// azBalancedSelector selects the least allocated node from the availability zone containing the
+// most nodes, so that removing the node keeps the class balanced across zones. Nodes without the
+// zone attribute are grouped together. When zones are equal in size, the zone name is used to
+// break the tie so the selection is deterministic.
+type azBalancedSelector struct {
+	attribute      string
+	leastAllocated *leastAllocatedSelector
+}
+
+func (s *azBalancedSelector) selectNode(nodes []*nodeInfo) *nodeInfo {
+	zones := make(map[string][]*nodeInfo)
+	for _, node := range nodes {
+		zone := node.attributes[s.attribute]
+		zones[zone] = append(zones[zone], node)
+	}
+
+	names := make([]string, 0, len(zones))
+	for zone := range zones {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+
+	var largest string
+	for _, zone := range names {
+		if len(zones[zone]) > len(zones[largest]) {
+			largest = zone
+		}
+	}
+	return s.leastAllocated.selectNode(zones[largest])
+}
+
+// randomSelector selects a node at random.
+type randomSelector struct {
+	intn func(n int) int
+}
+
+func (s *randomSelector) selectNode(nodes []*nodeInfo) *nodeInfo {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[s.intn(len(nodes))]
+}
+
+// value returns the allocated percentage of the passed resource. Resources which are not
+// tracked per node return 0.
+func (a *AllocatedStats) value(r state.ScaleResource) float64 {
+	switch r {
+	case state.ScaleResourceCPU:
+		return a.CPU
+	case state.ScaleResourceMemory:
+		return a.Memory
+	case state.ScaleResourceDisk:
+		return a.Disk
+	case state.ScaleResourceNetwork:
+		return a.Network
+	case state.ScaleResourceDevices:
+		return a.Devices
+	case state.ScaleResourceCPUUsed:
+		return a.CPUUsed
+	case state.ScaleResourceMemoryUsed:
+		return a.MemoryUsed
+	default:
+		return 0
+	}
+}
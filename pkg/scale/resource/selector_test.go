@@ -0,0 +1,192 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newNodeSelector(t *testing.T) {
+	h := &handler{}
+
+	// Each node is the target of at least one strategy, ensuring the strategies are independent.
+	nodes := []*nodeInfo{
+		{
+			ID:          "node-a",
+			createIndex: 10,
+			attributes:  map[string]string{state.DefaultAZAttribute: "eu-west-1a"},
+			allocations: map[string]*resources{"alloc-1": {}, "alloc-2": {}, "alloc-3": {}},
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000, disk: 1000},
+				allocatedResources:   &resources{cpu: 100, memory: 800, disk: 900},
+			},
+		},
+		{
+			ID:          "node-b",
+			createIndex: 30,
+			attributes:  map[string]string{state.DefaultAZAttribute: "eu-west-1b"},
+			allocations: map[string]*resources{"alloc-4": {}},
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000, disk: 1000},
+				allocatedResources:   &resources{cpu: 700, memory: 400, disk: 100},
+			},
+		},
+		{
+			ID:          "node-c",
+			createIndex: 20,
+			attributes:  map[string]string{state.DefaultAZAttribute: "eu-west-1b"},
+			allocations: map[string]*resources{"alloc-5": {}, "alloc-6": {}},
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000, disk: 1000},
+				allocatedResources:   &resources{cpu: 500, memory: 500, disk: 500},
+			},
+		},
+	}
+
+	testCases := []struct {
+		inputSelector  *state.ScaleInSelector
+		expectedOutput string
+		name           string
+	}{
+		{
+			inputSelector:  nil,
+			expectedOutput: "node-a",
+			name:           "default selector weights cpu and memory equally",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Resource: state.ScaleResourceCPU},
+			expectedOutput: "node-a",
+			name:           "least allocated cpu",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Resource: state.ScaleResourceMemory},
+			expectedOutput: "node-b",
+			name:           "least allocated memory",
+		},
+		{
+			inputSelector: &state.ScaleInSelector{
+				Weights: map[state.ScaleResource]float64{state.ScaleResourceCPU: 1, state.ScaleResourceDisk: 3},
+			},
+			expectedOutput: "node-b",
+			name:           "least allocated weighted score",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Strategy: state.NodeSelectorOldest},
+			expectedOutput: "node-a",
+			name:           "oldest node",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Strategy: state.NodeSelectorNewest},
+			expectedOutput: "node-b",
+			name:           "newest node",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Strategy: state.NodeSelectorFewestAllocs},
+			expectedOutput: "node-b",
+			name:           "fewest allocations",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Strategy: state.NodeSelectorAZBalanced},
+			expectedOutput: "node-c",
+			name:           "az balanced selects from largest zone",
+		},
+		{
+			inputSelector: &state.ScaleInSelector{
+				Strategy: state.NodeSelectorAZBalanced,
+				Resource: state.ScaleResourceMemory,
+			},
+			expectedOutput: "node-b",
+			name:           "az balanced with resource",
+		},
+		{
+			inputSelector: &state.ScaleInSelector{
+				Strategy:  state.NodeSelectorAZBalanced,
+				Attribute: "unique.platform.aws.instance-id",
+			},
+			expectedOutput: "node-a",
+			name:           "az balanced with missing attribute",
+		},
+		{
+			inputSelector:  &state.ScaleInSelector{Strategy: state.NodeSelectorRandom},
+			expectedOutput: "node-c",
+			name:           "random",
+		},
+	}
+
+	// The random function always returns the last index, making the random selection testable.
+	intn := func(n int) int { return n - 1 }
+
+	for _, tc := range testCases {
+		actualOutput := newNodeSelector(tc.inputSelector, h.calculateAllocatedPercentageStats, intn).selectNode(nodes)
+		assert.Equal(t, tc.expectedOutput, actualOutput.ID, tc.name)
+	}
+}
+
+func Test_newNodeSelectorNoNodes(t *testing.T) {
+	h := &handler{}
+	intn := func(n int) int { return n - 1 }
+
+	testCases := []struct {
+		inputStrategy state.NodeSelectorStrategy
+		name          string
+	}{
+		{inputStrategy: state.NodeSelectorLeastAllocated, name: "least allocated"},
+		{inputStrategy: state.NodeSelectorOldest, name: "oldest"},
+		{inputStrategy: state.NodeSelectorNewest, name: "newest"},
+		{inputStrategy: state.NodeSelectorFewestAllocs, name: "fewest allocations"},
+		{inputStrategy: state.NodeSelectorAZBalanced, name: "az balanced"},
+		{inputStrategy: state.NodeSelectorRandom, name: "random"},
+	}
+
+	for _, tc := range testCases {
+		selector := newNodeSelector(&state.ScaleInSelector{Strategy: tc.inputStrategy},
+			h.calculateAllocatedPercentageStats, intn)
+		assert.Nil(t, selector.selectNode(nil), tc.name)
+	}
+}
+
+func Test_leastAllocatedSelectorUsage(t *testing.T) {
+	h := &handler{}
+	selector := &state.ScaleInSelector{Resource: state.ScaleResourceCPUUsed}
+
+	newNode := func(id string, allocated float64, used *resources) *nodeInfo {
+		return &nodeInfo{
+			ID: id,
+			resourceStats: &resourceStats{
+				allocatableResources: &resources{cpu: 1000, memory: 1000},
+				allocatedResources:   &resources{cpu: allocated},
+				usedResources:        used,
+			},
+		}
+	}
+
+	testCases := []struct {
+		inputNodes     []*nodeInfo
+		expectedOutput string
+		name           string
+	}{
+		{
+			inputNodes: []*nodeInfo{
+				newNode("node-a", 800, nil),
+				newNode("node-b", 200, nil),
+			},
+			expectedOutput: "node-b",
+			name:           "usage not collected falls back to allocated",
+		},
+		{
+			inputNodes: []*nodeInfo{
+				newNode("node-a", 100, nil),
+				newNode("node-b", 700, &resources{cpu: 600}),
+				newNode("node-c", 700, &resources{cpu: 300}),
+			},
+			expectedOutput: "node-c",
+			name:           "node without collected usage not selected first",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := newNodeSelector(selector, h.calculateAllocatedPercentageStats, nil).selectNode(tc.inputNodes)
+		assert.Equal(t, tc.expectedOutput, actualOutput.ID, tc.name)
+	}
+}
@@ -0,0 +1,221 @@
+package resource
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	// usageCollectorWorkers is the maximum number of allocation resource usage requests made to
+	// Nomad concurrently.
+	usageCollectorWorkers = 10
+)
+
+// runUsageCollector periodically collects the actual resource usage of the running allocations on
+// every tracked node. Many jobs reserve more resources than they use, therefore the usage allows
+// checks to make decisions based on what is actually consumed within the class.
+func (h *handler) runUsageCollector() {
+	h.logger.Info().
+		Int("usage-interval", h.usageInterval).
+		Msg("starting Chemtrail resource usage collector")
+
+	t := time.NewTicker(time.Duration(h.usageInterval) * time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-h.nodeManager.shutdownChan:
+			h.logger.Info().Msg("shutting down Chemtrail resource usage collector")
+			return
+		case <-t.C:
+			h.collectUsage()
+		}
+	}
+}
+
+// collectUsage queries the Nomad clients for the resource usage of each running allocation and
+// stores the per node and per class totals. If the usage of any allocation on a node cannot be
+// collected, the node usage is stored as unavailable rather than under-reported.
+func (h *handler) collectUsage() {
+
+	// Take a snapshot of the running allocations so the lock is not held while calling the Nomad
+	// API. The snapshot is keyed by class, then by nodeID, and includes nodes without allocations
+	// so that their usage is reset.
+	h.nodeManager.nodePoolLock.RLock()
+	snapshot := make(map[string]map[string][]string, len(h.nodeManager.nodePool))
+	for class, info := range h.nodeManager.nodePool {
+		snapshot[class] = make(map[string][]string, len(info.nodes))
+		for nodeID, node := range info.nodes {
+			snapshot[class][nodeID] = make([]string, 0, len(node.allocations))
+			for allocID := range node.allocations {
+				snapshot[class][nodeID] = append(snapshot[class][nodeID], allocID)
+			}
+		}
+	}
+	h.nodeManager.nodePoolLock.RUnlock()
+
+	h.nodeManager.applyUsage(h.gatherUsage(snapshot, h.allocUsage))
+}
+
+// usageTarget identifies an allocation whose resource usage is to be collected.
+type usageTarget struct {
+	class   string
+	nodeID  string
+	allocID string
+}
+
+// gatherUsage collects the resource usage of each allocation within the snapshot using the fetch
+// function, totalling the usage of each node. Requests are made by a bounded number of workers so
+// that large clusters are collected within the interval without overwhelming Nomad. Allocations
+// which stopped after the snapshot was taken are skipped, whereas any other failure marks the node
+// usage as unavailable using a nil entry.
+func (h *handler) gatherUsage(snapshot map[string]map[string][]string, fetch func(allocID string) (*api.ResourceUsage, error)) map[string]map[string]*resources {
+	usage := make(map[string]map[string]*resources, len(snapshot))
+	for class, nodes := range snapshot {
+		usage[class] = make(map[string]*resources, len(nodes))
+		for nodeID := range nodes {
+			usage[class][nodeID] = &resources{}
+		}
+	}
+
+	targets := make(chan usageTarget)
+
+	var (
+		lock sync.Mutex
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < usageCollectorWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for t := range targets {
+				used, err := fetch(t.allocID)
+
+				// The stopped check takes the node pool lock, therefore it is performed before
+				// the usage lock is taken.
+				stopped := err != nil && h.allocStopped(t, err)
+
+				lock.Lock()
+				switch {
+				case err == nil:
+					if node := usage[t.class][t.nodeID]; node != nil {
+						node.add(resourceUsageToResources(used))
+					}
+				case stopped:
+					h.logger.Debug().
+						Str("alloc-id", t.allocID).
+						Str("node-id", t.nodeID).
+						Msg("skipping resource usage of stopped allocation")
+				default:
+					h.logger.Warn().
+						Err(err).
+						Str("alloc-id", t.allocID).
+						Str("node-id", t.nodeID).
+						Msg("failed to collect allocation resource usage, node usage unavailable")
+					usage[t.class][t.nodeID] = nil
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for class, nodes := range snapshot {
+		for nodeID, allocIDs := range nodes {
+			for _, allocID := range allocIDs {
+				targets <- usageTarget{class: class, nodeID: nodeID, allocID: allocID}
+			}
+		}
+	}
+	close(targets)
+	wg.Wait()
+
+	return usage
+}
+
+// allocUsage queries the resource usage of the allocation using the Nomad stats client, whose
+// requests are bounded by a timeout.
+func (h *handler) allocUsage(allocID string) (*api.ResourceUsage, error) {
+	stats, err := h.nodeManager.nomad.StatsClient.Allocations().Stats(&api.Allocation{ID: allocID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if stats.ResourceUsage == nil {
+		return nil, errors.New("allocation stats did not include resource usage")
+	}
+	return stats.ResourceUsage, nil
+}
+
+// allocStopped determines whether the failure to collect the usage of an allocation is because it
+// has stopped since the snapshot was taken. Nomad returns a not found error once the allocation
+// has been garbage collected from the client, otherwise the alloc watcher will have removed the
+// allocation from tracking.
+func (h *handler) allocStopped(t usageTarget, err error) bool {
+	if strings.Contains(err.Error(), "Unexpected response code: 404") {
+		return true
+	}
+
+	h.nodeManager.nodePoolLock.RLock()
+	defer h.nodeManager.nodePoolLock.RUnlock()
+
+	info, ok := h.nodeManager.nodePool[t.class]
+	if !ok {
+		return true
+	}
+	_, ok = info.allocations[t.allocID]
+	return !ok
+}
+
+// applyUsage stores the passed resource usage against each node, and totals the usage of the
+// nodes within each class. Nodes or classes which are no longer tracked are ignored. A nil node
+// usage marks the node, and therefore its class, as unavailable so usage checks are skipped
+// rather than acting on partial data.
+func (u *updateHandler) applyUsage(usage map[string]map[string]*resources) {
+	u.nodePoolLock.Lock()
+	defer u.nodePoolLock.Unlock()
+
+	for class, nodes := range usage {
+		info, ok := u.nodePool[class]
+		if !ok {
+			continue
+		}
+
+		classUsed := &resources{}
+
+		for nodeID, used := range nodes {
+			node, ok := info.nodes[nodeID]
+			if !ok {
+				continue
+			}
+			node.resourceStats.usedResources = used
+
+			if used == nil {
+				classUsed = nil
+				continue
+			}
+			if classUsed != nil {
+				classUsed.add(used)
+			}
+		}
+		info.resourceStats.usedResources = classUsed
+	}
+}
+
+// resourceUsageToResources converts the Nomad reported resource usage into the tracked resources.
+// CPU is measured in MHz via the total ticks, and memory in MB via the resident set size.
+func resourceUsageToResources(usage *api.ResourceUsage) *resources {
+	out := resources{}
+
+	if usage.CpuStats != nil {
+		out.cpu = usage.CpuStats.TotalTicks
+	}
+	if usage.MemoryStats != nil {
+		out.memory = float64(usage.MemoryStats.RSS) / 1024 / 1024
+	}
+	return &out
+}
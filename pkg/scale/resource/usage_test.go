@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_updateHandler_applyUsage(t *testing.T) {
+	u := &updateHandler{
+		nodePool: map[string]*classInfo{
+			"high-memory": {
+				class: "high-memory",
+				nodes: map[string]*nodeInfo{
+					"node-1": {ID: "node-1", resourceStats: &resourceStats{}},
+					"node-2": {ID: "node-2", resourceStats: &resourceStats{}},
+				},
+				resourceStats: &resourceStats{},
+			},
+		},
+	}
+
+	u.applyUsage(map[string]map[string]*resources{
+		"high-memory": {
+			"node-1":  {cpu: 100, memory: 256},
+			"node-2":  {cpu: 50, memory: 128},
+			"node-99": {cpu: 1000, memory: 1000},
+		},
+		"untracked": {
+			"node-3": {cpu: 10, memory: 10},
+		},
+	})
+
+	info := u.nodePool["high-memory"]
+	assert.Equal(t, &resources{cpu: 100, memory: 256}, info.nodes["node-1"].resourceStats.usedResources)
+	assert.Equal(t, &resources{cpu: 50, memory: 128}, info.nodes["node-2"].resourceStats.usedResources)
+	assert.Equal(t, &resources{cpu: 150, memory: 384}, info.resourceStats.usedResources)
+	assert.NotContains(t, u.nodePool, "untracked")
+
+	u.applyUsage(map[string]map[string]*resources{
+		"high-memory": {
+			"node-1": {cpu: 100, memory: 256},
+			"node-2": nil,
+		},
+	})
+
+	assert.Equal(t, &resources{cpu: 100, memory: 256}, info.nodes["node-1"].resourceStats.usedResources)
+	assert.Nil(t, info.nodes["node-2"].resourceStats.usedResources)
+	assert.Nil(t, info.resourceStats.usedResources)
+}
+
+func Test_handler_gatherUsage(t *testing.T) {
+	h := &handler{
+		logger: zerolog.Nop(),
+		nodeManager: &updateHandler{
+			nodePool: map[string]*classInfo{
+				"high-memory": {
+					class: "high-memory",
+					allocations: map[string]string{
+						"alloc-1": "running",
+						"alloc-2": "running",
+						"alloc-4": "running",
+					},
+				},
+			},
+		},
+	}
+
+	snapshot := map[string]map[string][]string{
+		"high-memory": {
+			"node-1": {"alloc-1", "alloc-2", "alloc-3"},
+			"node-2": {"alloc-4"},
+			"node-3": {},
+		},
+	}
+
+	fetch := func(allocID string) (*api.ResourceUsage, error) {
+		switch allocID {
+		case "alloc-1":
+			return &api.ResourceUsage{CpuStats: &api.CpuStats{TotalTicks: 100}}, nil
+		case "alloc-2":
+			return nil, errors.New("Unexpected response code: 404 (Unknown allocation)")
+		default:
+			return nil, errors.New("connection refused")
+		}
+	}
+
+	usage := h.gatherUsage(snapshot, fetch)
+
+	// The alloc which returned not found, and the alloc no longer tracked, are skipped. Whereas
+	// the failure of a running alloc marks the node usage as unavailable.
+	assert.Equal(t, &resources{cpu: 100}, usage["high-memory"]["node-1"])
+	assert.Nil(t, usage["high-memory"]["node-2"])
+	assert.Equal(t, &resources{}, usage["high-memory"]["node-3"])
+	assert.Contains(t, usage["high-memory"], "node-2")
+}
+
+func Test_resourceUsageToResources(t *testing.T) {
+	testCases := []struct {
+		inputUsage     *api.ResourceUsage
+		expectedOutput *resources
+		name           string
+	}{
+		{
+			inputUsage: &api.ResourceUsage{
+				CpuStats:    &api.CpuStats{TotalTicks: 250},
+				MemoryStats: &api.MemoryStats{RSS: 512 * 1024 * 1024},
+			},
+			expectedOutput: &resources{cpu: 250, memory: 512},
+			name:           "cpu and memory stats",
+		},
+		{
+			inputUsage:     &api.ResourceUsage{},
+			expectedOutput: &resources{},
+			name:           "no stats",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := resourceUsageToResources(tc.inputUsage)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -1,7 +1,10 @@
 package scale
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/jrasell/chemtrail/pkg/client"
 	serverCfg "github.com/jrasell/chemtrail/pkg/config/server"
@@ -27,8 +30,9 @@ type Scale interface {
 
 	// InvokeScaling triggers a scaling activity, all events from this point will be written to the
 	// state store. The function is designed to be called asynchronously, therefore there is no
-	// return.
-	InvokeScaling(req *state.ScalingRequest)
+	// return. Cancelling the context aborts the activity, stopping any drains or node join waits
+	// which are in progress.
+	InvokeScaling(ctx context.Context, req *state.ScalingRequest)
 }
 
 type BackendConfig struct {
@@ -53,6 +57,11 @@ type Backend struct {
 
 	// eventChan is used to listen and write scaling activity updates to the backend state store.
 	eventChan chan *state.EventMessage
+
+	// inProgress tracks the classes which currently have a scaling activity in progress, so that
+	// activities triggered by the autoscaler and the API do not run on the same class at once.
+	inProgress     map[string]bool
+	inProgressLock sync.Mutex
 }
 
 func NewScaleBackend(cfg *BackendConfig) Scale {
@@ -64,6 +73,7 @@ func NewScaleBackend(cfg *BackendConfig) Scale {
 		resourceHandler: cfg.NodeResources,
 		clientProvider:  make(map[state.ClientProvider]provider.ClientProvider),
 		eventChan:       make(chan *state.EventMessage, 10),
+		inProgress:      make(map[string]bool),
 	}
 
 	// If the AWS provider is enabled, configure the scaling backend.
@@ -105,6 +115,13 @@ func (b *Backend) OKToScale(req *state.ScalingRequest) (int, error) {
 		return http.StatusUnprocessableEntity, errScalingProviderNotFound
 	}
 
+	// Only a single scaling activity can run against a class at any one time. InvokeScaling also
+	// enforces this, as an activity may start between this check and the request being invoked.
+	if b.activityInProgress(req.Policy.Class) {
+		logger.Warn().Err(errScalingActivityInProgress).Msg(scalingPreconditionCheckFailedMsg)
+		return http.StatusConflict, errScalingActivityInProgress
+	}
+
 	// Check there are actually nodes within the class which has received the request to scale.
 	n := b.resourceHandler.GetNodesOfClass(req.Policy.Class)
 	if n == nil || len(n) < 1 {
@@ -112,19 +129,46 @@ func (b *Backend) OKToScale(req *state.ScalingRequest) (int, error) {
 		return http.StatusUnprocessableEntity, errNoNodesFoundInClass
 	}
 
+	// If the policy is configured to bin-pack, calculate the number of nodes to add unless the
+	// request has already specified the count.
+	if req.Direction == state.ScaleDirectionOut && req.Count == 0 &&
+		req.Policy.GetScaleOutMode() == state.ScaleOutModeBinPack {
+		if err := b.calculateBinPackCount(logger, req); err != nil {
+			logger.Warn().Err(err).Msg(scalingPreconditionCheckFailedMsg)
+			return http.StatusPreconditionFailed, err
+		}
+	}
+
 	// Check the new count does not break any thresholds.
-	code, err := b.checkNewCount(req.Policy, req.Direction)
+	code, err := b.checkNewCount(req)
 	if err != nil {
 		logger.Warn().Err(err).Msg(scalingPreconditionCheckFailedMsg)
+		return code, err
+	}
+
+	// When scaling in, ensure the allocations of the node which will be removed can be placed on
+	// the remaining nodes of the class, otherwise they would be left pending.
+	if req.Direction == state.ScaleDirectionIn {
+		code, err = b.checkScaleInPlacement(req)
+		if err != nil {
+			logger.Warn().Err(err).Msg(scalingPreconditionCheckFailedMsg)
+		}
 	}
 	return code, err
 }
 
 // InvokeScaling satisfies the InvokeScaling function on the Scale interface.
-func (b *Backend) InvokeScaling(req *state.ScalingRequest) {
+func (b *Backend) InvokeScaling(ctx context.Context, req *state.ScalingRequest) {
 	// Create a temporary logger so that every log line includes the targeted class.
 	logger := helper.LoggerWithNodeClassContext(b.logger, req.Policy.Class)
 
+	// If the activity was aborted before it started, the cluster has not been altered and there is
+	// nothing to record.
+	if ctx.Err() != nil {
+		logger.Info().Object("request", req).Msg("scaling activity aborted before starting")
+		return
+	}
+
 	logger.Info().
 		Object("request", req).
 		Msg("performing scaling activity")
@@ -134,7 +178,28 @@ func (b *Backend) InvokeScaling(req *state.ScalingRequest) {
 		logger.Error().Err(err).Msg("failed to write initial state entry")
 		return
 	}
-	err := b.invokeScaling(req)
+
+	// The class is claimed for the duration of the activity. If another activity started on the
+	// class after the precondition checks passed, this activity is recorded as failed.
+	var err error
+
+	if b.startActivity(req.Policy.Class) {
+		defer b.finishActivity(req.Policy.Class)
+
+		// If a bin-packing simulation was used to size the activity, record the result so
+		// operators can understand how the count was calculated.
+		if req.Simulation != nil {
+			b.eventChan <- &state.EventMessage{
+				ID:        req.ID,
+				Timestamp: helper.GenerateEventTimestamp(),
+				Source:    eventSourceSimulation,
+				Message:   req.Simulation.String(),
+			}
+		}
+		err = b.invokeScaling(ctx, req)
+	} else {
+		err = errScalingActivityInProgress
+	}
 
 	// Log the outcome of the scaling activity.
 	if err != nil {
@@ -152,44 +217,126 @@ func (b *Backend) InvokeScaling(req *state.ScalingRequest) {
 	}
 }
 
-func (b *Backend) invokeScaling(req *state.ScalingRequest) error {
+func (b *Backend) invokeScaling(ctx context.Context, req *state.ScalingRequest) error {
 	switch req.Direction {
 	case state.ScaleDirectionOut:
-		return b.clientProvider[req.Policy.Provider].ScaleOut(req)
+		return b.scaleOut(ctx, req)
 
 	case state.ScaleDirectionIn:
-		// If we are scaling in, we need to discover the node we will target.
-		node := b.resourceHandler.GetLeastAllocatedNodeInClass(req.Policy.Class)
-		if node == nil {
-			return errors.New("failed to discover least allocated node in class")
+		// If we are scaling in, we need to discover the nodes we will target if this was not
+		// performed during the precondition checks.
+		if len(req.TargetNodeIDs) == 0 {
+			targets, err := b.selectScaleInTargets(req)
+			if err != nil {
+				return err
+			}
+			req.TargetNodeIDs = targets
 		}
-		req.TargetNodeID = node.ID
 
-		// If we are using the NoOp provider, we should not remove the node from the cluster.
+		// If we are using the NoOp provider, we should not remove the nodes from the cluster.
+		nodeIDs := req.TargetNodeIDs
+
+		var drainErr error
+
 		if req.Policy.Provider != state.NoOpClientProvider {
-			if err := b.removeNodeFromCluster(req.TargetNodeID, req.ID); err != nil {
-				return err
+			nodeIDs, drainErr = b.drainNodes(ctx, req.TargetNodeIDs, req.ID, req.Policy.Drain)
+			if len(nodeIDs) == 0 {
+				return drainErr
 			}
 		}
 
-		target, err := b.identifyProviderTarget(req)
-		if err != nil {
+		// If the activity was aborted, the drained nodes are not terminated. They are returned
+		// to service instead, so another server can scale the class using an accurate view.
+		if ctx.Err() != nil {
+			for _, nodeID := range nodeIDs {
+				b.cancelNodeDrain(req.ID, nodeID, "activity aborted")
+			}
+			return fmt.Errorf("%v: %v", errScalingActivityAborted, ctx.Err())
+		}
+
+		// Nodes which drained successfully are terminated even if others failed to drain, as they
+		// no longer run any work and would otherwise be left ineligible within the cluster.
+		if err := b.terminateNodes(req, nodeIDs); err != nil {
 			return err
 		}
-		return b.clientProvider[req.Policy.Provider].ScaleIn(req, target)
+		return drainErr
 
 	default:
 		return errors.Errorf("unsupported scaling direction for invoke: %s", req.Direction.String())
 	}
 }
 
-func (b *Backend) identifyProviderTarget(req *state.ScalingRequest) (string, error) {
+// terminateNodes removes the nodes using the policy provider, recording the outcome of each node
+// within the scaling activity. If the provider reports the targets which failed, only their nodes
+// are recorded as failed.
+func (b *Backend) terminateNodes(req *state.ScalingRequest, nodeIDs []string) error {
+	targets := make([]string, len(nodeIDs))
+
+	var err error
+
+	for i, nodeID := range nodeIDs {
+		if targets[i], err = b.identifyProviderTarget(req, nodeID); err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		err = b.clientProvider[req.Policy.Provider].ScaleIn(req, targets)
+	}
+
+	scaleInErr, partial := err.(*provider.ScaleInError)
+
+	for i, nodeID := range nodeIDs {
+		nodeErr := err
+		if partial {
+			nodeErr = scaleInErr.Errors[targets[i]]
+		}
+
+		if nodeErr != nil {
+			b.sendNodeEvent(req.ID, nodeID, state.ScaleStatusFailed,
+				fmt.Sprintf("failed to remove node %s using provider: %v", nodeID, nodeErr))
+			continue
+		}
+		b.sendNodeEvent(req.ID, nodeID, state.ScaleStatusCompleted,
+			fmt.Sprintf("successfully removed node %s using provider", nodeID))
+	}
+	return err
+}
+
+func (b *Backend) identifyProviderTarget(req *state.ScalingRequest, nodeID string) (string, error) {
 	switch req.Policy.Provider {
 	case state.AWSAutoScaling:
-		return b.nodeIDToAWSInstanceID(req.TargetNodeID, req.ID)
+		return b.nodeIDToAWSInstanceID(nodeID, req.ID)
 	case state.NoOpClientProvider:
-		return req.TargetNodeID, nil
+		return nodeID, nil
 	default:
 		return "", errors.Errorf("unsupported provider: %s", req.Policy.Provider.String())
 	}
 }
+
+// startActivity marks the class as having a scaling activity in progress. If the class already has
+// an activity in progress, false is returned and the new activity must not be run.
+func (b *Backend) startActivity(class string) bool {
+	b.inProgressLock.Lock()
+	defer b.inProgressLock.Unlock()
+
+	if b.inProgress[class] {
+		return false
+	}
+	b.inProgress[class] = true
+	return true
+}
+
+// finishActivity marks the scaling activity of the class as no longer in progress.
+func (b *Backend) finishActivity(class string) {
+	b.inProgressLock.Lock()
+	delete(b.inProgress, class)
+	b.inProgressLock.Unlock()
+}
+
+// activityInProgress returns whether the class currently has a scaling activity in progress.
+func (b *Backend) activityInProgress(class string) bool {
+	b.inProgressLock.Lock()
+	defer b.inProgressLock.Unlock()
+	return b.inProgress[class]
+}
@@ -0,0 +1,110 @@
+package scale
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jrasell/chemtrail/pkg/scale/provider"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProvider is a provider.ClientProvider which returns the configured error from ScaleIn.
+type testProvider struct {
+	scaleInErr error
+}
+
+func (p *testProvider) Name() string                                      { return "test" }
+func (p *testProvider) ScaleIn(_ *state.ScalingRequest, _ []string) error { return p.scaleInErr }
+func (p *testProvider) ScaleOut(_ *state.ScalingRequest) error            { return nil }
+
+func TestBackend_terminateNodes(t *testing.T) {
+	testCases := []struct {
+		inputErr       error
+		expectedStatus map[string]state.ScaleStatus
+		expectError    bool
+		name           string
+	}{
+		{
+			inputErr: nil,
+			expectedStatus: map[string]state.ScaleStatus{
+				"node-a": state.ScaleStatusCompleted,
+				"node-b": state.ScaleStatusCompleted,
+			},
+			expectError: false,
+			name:        "all nodes removed",
+		},
+		{
+			inputErr: errors.New("provider unavailable"),
+			expectedStatus: map[string]state.ScaleStatus{
+				"node-a": state.ScaleStatusFailed,
+				"node-b": state.ScaleStatusFailed,
+			},
+			expectError: true,
+			name:        "all nodes failed",
+		},
+		{
+			inputErr: &provider.ScaleInError{Errors: map[string]error{"node-b": errors.New("batch failed")}},
+			expectedStatus: map[string]state.ScaleStatus{
+				"node-a": state.ScaleStatusCompleted,
+				"node-b": state.ScaleStatusFailed,
+			},
+			expectError: true,
+			name:        "some nodes failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		b := &Backend{
+			clientProvider: map[state.ClientProvider]provider.ClientProvider{
+				state.NoOpClientProvider: &testProvider{scaleInErr: tc.inputErr},
+			},
+			eventChan: make(chan *state.EventMessage, 10),
+		}
+		req := &state.ScalingRequest{
+			Direction: state.ScaleDirectionIn,
+			Policy:    &state.ClientScalingPolicy{Class: "test-class", Provider: state.NoOpClientProvider},
+		}
+
+		err := b.terminateNodes(req, []string{"node-a", "node-b"})
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+
+		assert.Len(t, b.eventChan, len(tc.expectedStatus), tc.name)
+		for range tc.expectedStatus {
+			event := <-b.eventChan
+			assert.Equal(t, tc.expectedStatus[event.NodeID], event.NodeStatus, tc.name)
+		}
+	}
+}
+
+func TestBackend_activityInProgress(t *testing.T) {
+	b := &Backend{
+		logger: zerolog.Nop(),
+		clientProvider: map[state.ClientProvider]provider.ClientProvider{
+			state.NoOpClientProvider: &testProvider{},
+		},
+		inProgress: make(map[string]bool),
+	}
+	req := &state.ScalingRequest{
+		Direction: state.ScaleDirectionIn,
+		Policy:    &state.ClientScalingPolicy{Class: "test-class", Enabled: true, Provider: state.NoOpClientProvider},
+	}
+
+	assert.True(t, b.startActivity("test-class"))
+	assert.False(t, b.startActivity("test-class"))
+	assert.True(t, b.startActivity("other-class"))
+
+	code, err := b.OKToScale(req)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, errScalingActivityInProgress, err)
+
+	b.finishActivity("test-class")
+	assert.False(t, b.activityInProgress("test-class"))
+	assert.True(t, b.startActivity("test-class"))
+}
@@ -7,12 +7,13 @@ import (
 // Config contains all the required configuration to setup and run a Chemtrail server as defined by
 // an operator.
 type Config struct {
-	Autoscale *serverCfg.AutoscalerConfig
-	Provider  *serverCfg.ProviderConfig
-	Server    *serverCfg.Config
-	Storage   *serverCfg.StorageConfig
-	TLS       *serverCfg.TLSConfig
-	Telemetry *serverCfg.TelemetryConfig
+	Autoscale    *serverCfg.AutoscalerConfig
+	MetricSource *serverCfg.MetricSourceConfig
+	Provider     *serverCfg.ProviderConfig
+	Server       *serverCfg.Config
+	Storage      *serverCfg.StorageConfig
+	TLS          *serverCfg.TLSConfig
+	Telemetry    *serverCfg.TelemetryConfig
 }
 
 // The system API endpoints.
@@ -21,7 +22,17 @@ const (
 	routeGetSystemMetricsPattern = "/v1/system/metrics"
 	routeGetSystemHealthName     = "GetSystemHealth"
 	routeGetSystemHealthPattern  = "/v1/system/health"
-	telemetryInterval            = 10
+	routeGetSystemLeaderName     = "GetSystemLeader"
+	routeGetSystemLeaderPattern  = "/v1/system/leader"
+
+	routeGetAutoscalerStatusName    = "GetAutoscalerStatus"
+	routeGetAutoscalerStatusPattern = "/v1/system/autoscaler"
+	routePauseAutoscalerName        = "PauseAutoscaler"
+	routePauseAutoscalerPattern     = "/v1/system/autoscaler/pause"
+	routeResumeAutoscalerName       = "ResumeAutoscaler"
+	routeResumeAutoscalerPattern    = "/v1/system/autoscaler/resume"
+
+	telemetryInterval = 10
 )
 
 // The policy API endpoints.
@@ -34,6 +45,11 @@ const (
 	routePutPolicyPattern    = "/v1/policy/{client-class}"
 	routeDeletePolicyName    = "DeletePolicy"
 	routeDeletePolicyPattern = "/v1/policy/{client-class}"
+
+	routeGetPolicySchedulesName    = "GetPolicySchedules"
+	routeGetPolicySchedulesPattern = "/v1/policy/{client-class}/schedules"
+	routeEvaluatePolicyName        = "EvaluatePolicy"
+	routeEvaluatePolicyPattern     = "/v1/policy/{client-class}/evaluate"
 )
 
 // The scale API endpoints.
@@ -47,3 +63,19 @@ const (
 	routeScaleOutName              = "PostScaleOut"
 	routeScaleOutPattern           = "/v1/scale/out/{client-class}"
 )
+
+// The evaluation API endpoints.
+const (
+	routeGetEvaluationsName         = "GetEvaluations"
+	routeGetEvaluationsPattern      = "/v1/evaluations"
+	routeGetClassEvaluationsName    = "GetClassEvaluations"
+	routeGetClassEvaluationsPattern = "/v1/evaluations/{client-class}"
+)
+
+// The node API endpoints.
+const (
+	routeGetNodesName         = "GetNodes"
+	routeGetNodesPattern      = "/v1/nodes"
+	routeGetClassNodesName    = "GetClassNodes"
+	routeGetClassNodesPattern = "/v1/nodes/{client-class}"
+)
@@ -0,0 +1,55 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/rs/zerolog"
+)
+
+type Server struct {
+	logger       zerolog.Logger
+	scaleBackend state.ScaleBackend
+}
+
+func NewServer(log zerolog.Logger, scaleBackend state.ScaleBackend) *Server {
+	return &Server{
+		logger:       log.With().Str("component", "endpoint-evaluation").Logger(),
+		scaleBackend: scaleBackend,
+	}
+}
+
+func (s *Server) GetEvaluations(w http.ResponseWriter, r *http.Request) {
+	evaluations, err := s.scaleBackend.GetEvaluations()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bytes, err := json.Marshal(evaluations)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, bytes, http.StatusOK, s.logger)
+}
+
+func (s *Server) GetClassEvaluations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	evaluations, err := s.scaleBackend.GetClassEvaluations(vars["client-class"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bytes, err := json.Marshal(evaluations)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, bytes, http.StatusOK, s.logger)
+}
@@ -0,0 +1,41 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/scale/resource"
+	"github.com/rs/zerolog"
+)
+
+type Server struct {
+	logger          zerolog.Logger
+	resourceHandler resource.Handler
+}
+
+func NewServer(log zerolog.Logger, resourceHandler resource.Handler) *Server {
+	return &Server{
+		logger:          log.With().Str("component", "endpoint-node").Logger(),
+		resourceHandler: resourceHandler,
+	}
+}
+
+func (s *Server) GetNodes(w http.ResponseWriter, r *http.Request) {
+	s.writeNodes(w, "")
+}
+
+func (s *Server) GetClassNodes(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	s.writeNodes(w, vars["client-class"])
+}
+
+func (s *Server) writeNodes(w http.ResponseWriter, class string) {
+	bytes, err := json.Marshal(s.resourceHandler.GetNodes(class))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, bytes, http.StatusOK, s.logger)
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/scale/auto"
 	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/rs/zerolog"
 )
@@ -14,12 +16,14 @@ import (
 type Server struct {
 	logger        zerolog.Logger
 	policyBackend state.PolicyBackend
+	autoscaler    *auto.Scale
 }
 
-func NewServer(log zerolog.Logger, policyBackend state.PolicyBackend) *Server {
+func NewServer(log zerolog.Logger, policyBackend state.PolicyBackend, autoscaler *auto.Scale) *Server {
 	return &Server{
 		logger:        log.With().Str("component", "endpoint-policy").Logger(),
 		policyBackend: policyBackend,
+		autoscaler:    autoscaler,
 	}
 }
 
@@ -61,6 +65,35 @@ func (s *Server) GetPolicy(w http.ResponseWriter, r *http.Request) {
 	helper.WriteJSONResponse(w, bytes, http.StatusOK, s.logger)
 }
 
+func (s *Server) GetPolicySchedules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	class := vars["client-class"]
+
+	policy, err := s.policyBackend.GetPolicy(class)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if policy == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	windows, err := policy.GetScheduleWindows(time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bytes, err := json.Marshal(windows)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, bytes, http.StatusOK, s.logger)
+}
+
 func (s *Server) PutPolicy(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	class := vars["client-class"]
@@ -95,6 +128,54 @@ func (s *Server) PutPolicy(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// EvaluatePolicy performs a dry-run evaluation of the class scaling policy and returns the
+// resulting evaluation record. If the request includes a policy body, this is evaluated in place of
+// the stored policy, allowing policies to be tested before being written.
+func (s *Server) EvaluatePolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	class := vars["client-class"]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to read request body")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var p *state.ClientScalingPolicy
+
+	if len(body) == 0 {
+		if p, err = s.policyBackend.GetPolicy(class); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if p == nil {
+			http.NotFound(w, r)
+			return
+		}
+	} else {
+		if err := json.Unmarshal(body, &p); err != nil {
+			s.logger.Error().Err(err).Msg("failed to unmarshal request body into policy")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		p.Class = class
+
+		if err := p.Validate(); err != nil {
+			s.logger.Error().Err(err).Msg("failed to validate scale policy")
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	bytes, err := json.Marshal(s.autoscaler.Plan(p))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, bytes, http.StatusOK, s.logger)
+}
+
 func (s *Server) DeletePolicy(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	class := vars["client-class"]
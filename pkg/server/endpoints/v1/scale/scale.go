@@ -1,6 +1,7 @@
 package scale
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -13,8 +14,13 @@ import (
 )
 
 type Server struct {
-	Logger        zerolog.Logger
-	Scale         scale.Scale
+	Logger zerolog.Logger
+	Scale  scale.Scale
+
+	// LeaderContext returns the context of the current leadership term. Scaling activities are
+	// invoked using it, so they are aborted if the server loses leadership.
+	LeaderContext func() context.Context
+
 	PolicyBackend state.PolicyBackend
 	ScaleBackend  state.ScaleBackend
 }
@@ -33,12 +39,17 @@ func (s *Server) PostScaleIn(w http.ResponseWriter, r *http.Request) {
 	}
 	msg.Direction = state.ScaleDirectionIn
 
+	ctx, ok := s.leaderContext(w)
+	if !ok {
+		return
+	}
+
 	code, err := s.Scale.OKToScale(msg)
 	if err != nil {
 		http.Error(w, err.Error(), code)
 		return
 	}
-	go s.Scale.InvokeScaling(msg)
+	go s.Scale.InvokeScaling(ctx, msg)
 
 	helper.WriteJSONResponse(w, []byte(fmt.Sprintf("{\"ID\":\"%s\"}", msg.ID)), http.StatusOK, s.Logger)
 }
@@ -56,16 +67,32 @@ func (s *Server) PostScaleOut(w http.ResponseWriter, r *http.Request) {
 	}
 	msg.Direction = state.ScaleDirectionOut
 
+	ctx, ok := s.leaderContext(w)
+	if !ok {
+		return
+	}
+
 	code, err := s.Scale.OKToScale(msg)
 	if err != nil {
 		http.Error(w, err.Error(), code)
 		return
 	}
-	go s.Scale.InvokeScaling(msg)
+	go s.Scale.InvokeScaling(ctx, msg)
 
 	helper.WriteJSONResponse(w, []byte(fmt.Sprintf("{\"ID\":\"%s\"}", msg.ID)), http.StatusOK, s.Logger)
 }
 
+// leaderContext returns the context of the current leadership term. If leadership was lost after
+// the request was accepted, the activity cannot be performed and an error response is written.
+func (s *Server) leaderContext(w http.ResponseWriter) (context.Context, bool) {
+	ctx := s.LeaderContext()
+	if ctx.Err() != nil {
+		http.Error(w, "server is no longer the leader", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	return ctx, true
+}
+
 func (s *Server) prepareScaleMessage(vars map[string]string) (*state.ScalingRequest, error) {
 	targetClass := vars["client-class"]
 
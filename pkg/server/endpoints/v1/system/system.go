@@ -2,22 +2,35 @@ package system
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"time"
 
 	metrics "github.com/armon/go-metrics"
 	"github.com/jrasell/chemtrail/pkg/helper"
+	"github.com/jrasell/chemtrail/pkg/leader"
+	"github.com/jrasell/chemtrail/pkg/state"
 	"github.com/rs/zerolog"
 )
 
 type Server struct {
 	logger    zerolog.Logger
 	telemetry *metrics.InmemSink
+
+	// autoscalerEnabled indicates whether the server has been configured to run the autoscaler.
+	autoscalerEnabled bool
+	pauseBackend      state.PauseBackend
+	elector           leader.Elector
 }
 
-func NewServer(logger zerolog.Logger, telemetry *metrics.InmemSink) *Server {
+func NewServer(logger zerolog.Logger, telemetry *metrics.InmemSink, autoscalerEnabled bool,
+	pauseBackend state.PauseBackend, elector leader.Elector) *Server {
 	return &Server{
-		logger:    logger.With().Str("component", "endpoint-system").Logger(),
-		telemetry: telemetry,
+		logger:            logger.With().Str("component", "endpoint-system").Logger(),
+		telemetry:         telemetry,
+		autoscalerEnabled: autoscalerEnabled,
+		pauseBackend:      pauseBackend,
+		elector:           elector,
 	}
 }
 
@@ -42,3 +55,110 @@ func (s *Server) GetMetrics(w http.ResponseWriter, r *http.Request) {
 
 	helper.WriteJSONResponse(w, out, http.StatusOK, s.logger)
 }
+
+// GetLeader returns the advertised address of the current leader, and whether this server is the
+// leader.
+func (s *Server) GetLeader(w http.ResponseWriter, r *http.Request) {
+	addr, err := s.elector.Leader()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to get current leader")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	out, err := json.Marshal(state.LeaderStatus{Leader: addr, IsLeader: s.elector.IsLeader()})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to marshal HTTP response")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, out, http.StatusOK, s.logger)
+}
+
+// GetAutoscalerStatus returns the current status of the autoscaler, including any active pause.
+func (s *Server) GetAutoscalerStatus(w http.ResponseWriter, r *http.Request) {
+	s.writeAutoscalerStatus(w)
+}
+
+// PauseAutoscaler pauses the autoscaler, optionally for a duration, and returns the updated
+// autoscaler status. Pausing an already paused autoscaler replaces the existing pause.
+func (s *Server) PauseAutoscaler(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to read request body")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var req state.AutoscalerPauseRequest
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			s.logger.Error().Err(err).Msg("failed to unmarshal request body into pause request")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if req.Duration < 0 {
+		http.Error(w, "Duration must not be negative", http.StatusUnprocessableEntity)
+		return
+	}
+
+	pause := state.AutoscalerPause{
+		Reason:    req.Reason,
+		Timestamp: helper.GenerateEventTimestamp(),
+	}
+	if req.Duration > 0 {
+		pause.Expiry = pause.Timestamp + (time.Duration(req.Duration) * time.Second).Nanoseconds()
+	}
+
+	if err := s.pauseBackend.PutPause(&pause); err != nil {
+		s.logger.Error().Err(err).Msg("failed to write autoscaler pause to storage backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info().
+		Str("pause-reason", pause.Reason).
+		Int("pause-duration", req.Duration).
+		Msg("autoscaler has been paused")
+
+	s.writeAutoscalerStatus(w)
+}
+
+// ResumeAutoscaler removes any pause of the autoscaler and returns the updated autoscaler status.
+func (s *Server) ResumeAutoscaler(w http.ResponseWriter, r *http.Request) {
+	if err := s.pauseBackend.DeletePause(); err != nil {
+		s.logger.Error().Err(err).Msg("failed to delete autoscaler pause from storage backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info().Msg("autoscaler has been resumed")
+
+	s.writeAutoscalerStatus(w)
+}
+
+func (s *Server) writeAutoscalerStatus(w http.ResponseWriter) {
+	pause, err := s.pauseBackend.GetPause()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := state.AutoscalerStatus{Enabled: s.autoscalerEnabled}
+
+	if pause.IsActive(helper.GenerateEventTimestamp()) {
+		status.Paused = true
+		status.Pause = pause
+	}
+
+	out, err := json.Marshal(status)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to marshal HTTP response")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	helper.WriteJSONResponse(w, out, http.StatusOK, s.logger)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// headerForwardedBy is set on requests forwarded to the leader, identifying the forwarding server.
+// Requests which have already been forwarded are never forwarded again, avoiding loops while
+// leadership changes.
+const headerForwardedBy = "X-Chemtrail-Forwarded-By"
+
+// forwardToLeader wraps a handler which must only be run by the leader. When this server is not
+// the leader, the request is transparently forwarded to the leader and its response returned to
+// the caller.
+func (h *HTTPServer) forwardToLeader(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.elector.IsLeader() {
+			handler(w, r)
+			return
+		}
+
+		addr, err := h.elector.Leader()
+		if err != nil {
+			h.logger.Error().Err(err).Msg("failed to get current leader to forward request")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// If no leader is elected, or the leader address is this server which is no longer the
+		// leader, the request cannot currently be handled.
+		if addr == "" || addr == h.advertiseAddr() || r.Header.Get(headerForwardedBy) != "" {
+			http.Error(w, "no leader is currently elected to handle request", http.StatusServiceUnavailable)
+			return
+		}
+
+		target, err := url.Parse(addr)
+		if err != nil {
+			h.logger.Error().Err(err).Str("leader", addr).Msg("failed to parse leader address")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		h.logger.Debug().
+			Str("leader", addr).
+			Str("path", r.URL.Path).
+			Msg("forwarding request to leader")
+
+		proxy := httputil.NewSingleHostReverseProxy(target)
+
+		director := proxy.Director
+		proxy.Director = func(req *http.Request) {
+			director(req)
+			req.Host = target.Host
+			req.Header.Set(headerForwardedBy, h.advertiseAddr())
+		}
+
+		proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+			h.logger.Error().Err(err).Str("leader", addr).Msg("failed to forward request to leader")
+			http.Error(w, fmt.Sprintf("failed to forward request to leader %s: %v", addr, err), http.StatusBadGateway)
+		}
+
+		proxy.ServeHTTP(w, r)
+	}
+}
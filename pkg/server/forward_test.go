@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serverCfg "github.com/jrasell/chemtrail/pkg/config/server"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeElector struct {
+	isLeader bool
+	leader   string
+}
+
+func (f *fakeElector) Run(_, _ func())         {}
+func (f *fakeElector) Stop()                   {}
+func (f *fakeElector) IsLeader() bool          { return f.isLeader }
+func (f *fakeElector) Leader() (string, error) { return f.leader, nil }
+
+func TestHTTPServer_forwardToLeader(t *testing.T) {
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "http://127.0.0.1:8001", r.Header.Get(headerForwardedBy))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer leader.Close()
+
+	local := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) }
+
+	testCases := []struct {
+		inputElector   *fakeElector
+		inputForwarded bool
+		expectedCode   int
+		name           string
+	}{
+		{
+			inputElector: &fakeElector{isLeader: true, leader: "http://127.0.0.1:8001"},
+			expectedCode: http.StatusTeapot,
+			name:         "leader handles request",
+		},
+		{
+			inputElector: &fakeElector{leader: leader.URL},
+			expectedCode: http.StatusCreated,
+			name:         "follower forwards request",
+		},
+		{
+			inputElector: &fakeElector{},
+			expectedCode: http.StatusServiceUnavailable,
+			name:         "no leader elected",
+		},
+		{
+			inputElector:   &fakeElector{leader: leader.URL},
+			inputForwarded: true,
+			expectedCode:   http.StatusServiceUnavailable,
+			name:           "previously forwarded request",
+		},
+	}
+
+	for _, tc := range testCases {
+		h := HTTPServer{
+			addr:    "127.0.0.1:8001",
+			cfg:     &Config{Server: &serverCfg.Config{}, TLS: &serverCfg.TLSConfig{}},
+			logger:  zerolog.Nop(),
+			elector: tc.inputElector,
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/scale/out/test", nil)
+		if tc.inputForwarded {
+			req.Header.Set(headerForwardedBy, "http://127.0.0.1:8002")
+		}
+		rec := httptest.NewRecorder()
+
+		h.forwardToLeader(local)(rec, req)
+		assert.Equal(t, tc.expectedCode, rec.Code, tc.name)
+	}
+}
@@ -7,8 +7,8 @@ import "time"
 var gcEvalPeriod = time.Minute * 10
 
 // runGarbageCollectionLoop is responsible for periodically running the scaling state garbage
-// collection function.
-func (h *HTTPServer) runGarbageCollectionLoop() {
+// collection function until the passed channel is closed.
+func (h *HTTPServer) runGarbageCollectionLoop(stopChan <-chan struct{}) {
 	h.logger.Info().Msg("started scaling state garbage collector handler")
 
 	h.gcIsRunning = true
@@ -18,7 +18,7 @@ func (h *HTTPServer) runGarbageCollectionLoop() {
 
 	for {
 		select {
-		case <-h.stopChan:
+		case <-stopChan:
 			h.logger.Info().Msg("shutting down state garbage collection handler")
 			h.gcIsRunning = false
 			return
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	leaderConsul "github.com/jrasell/chemtrail/pkg/leader/consul"
+	leaderLocal "github.com/jrasell/chemtrail/pkg/leader/local"
+)
+
+// setupLeaderElection sets up the leader elector. When state is stored in Consul, multiple
+// Chemtrail servers may share it and therefore leadership is elected using a Consul lock.
+// Otherwise the server is always the leader.
+func (h *HTTPServer) setupLeaderElection() {
+	if h.cfg.Storage.ConsulEnabled {
+		h.logger.Debug().Msg("setting up Consul leader election")
+		h.elector = leaderConsul.NewElector(h.logger, h.advertiseAddr(), h.cfg.Storage.ConsulPath, h.consul)
+	} else {
+		h.elector = leaderLocal.NewElector(h.advertiseAddr())
+	}
+}
+
+// advertiseAddr returns the address of the server advertised to other Chemtrail servers. If the
+// operator has not configured the address, it is built using the bind address and port.
+func (h *HTTPServer) advertiseAddr() string {
+	if h.cfg.Server.AdvertiseAddr != "" {
+		return h.cfg.Server.AdvertiseAddr
+	}
+
+	scheme := "http"
+	if h.cfg.TLS.CertPath != "" && h.cfg.TLS.CertKeyPath != "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, h.addr)
+}
+
+// startLeaderProcesses starts the processes which must only be run by the leader, once this server
+// has acquired leadership. Followers continue to run the resource watchers, so that their
+// resource state is ready should they take over.
+func (h *HTTPServer) startLeaderProcesses() {
+	h.logger.Info().Msg("starting leader processes")
+
+	h.leaderStopChan = make(chan struct{})
+
+	h.leaderLock.Lock()
+	h.leaderCtx, h.leaderCancel = context.WithCancel(context.Background())
+	h.leaderLock.Unlock()
+
+	if h.cfg.Autoscale.Enabled {
+		h.autoscaler.Run()
+	}
+
+	// Trigger the garbage collection periodic loop.
+	go h.runGarbageCollectionLoop(h.leaderStopChan)
+}
+
+// stopLeaderProcesses stops the processes which must only be run by the leader, once this server
+// has lost leadership. In-flight autoscaler activities are aborted rather than waited upon, so that
+// this server stops altering the cluster before another server takes over.
+func (h *HTTPServer) stopLeaderProcesses() {
+	h.logger.Info().Msg("stopping leader processes")
+
+	// Abort the scaling activities requested via the API during this leadership term.
+	h.leaderLock.Lock()
+	h.leaderCancel()
+	h.leaderLock.Unlock()
+
+	h.autoscaler.Stop()
+	close(h.leaderStopChan)
+}
+
+// leaderContext returns the context of the current leadership term, which is cancelled once this
+// server loses leadership. If the server has never been the leader, a cancelled context is
+// returned.
+func (h *HTTPServer) leaderContext() context.Context {
+	h.leaderLock.RLock()
+	defer h.leaderLock.RUnlock()
+
+	if h.leaderCtx == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	}
+	return h.leaderCtx
+}
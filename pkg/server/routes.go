@@ -3,6 +3,8 @@ package server
 import (
 	"net/http"
 
+	evaluationV1 "github.com/jrasell/chemtrail/pkg/server/endpoints/v1/evaluation"
+	nodeV1 "github.com/jrasell/chemtrail/pkg/server/endpoints/v1/node"
 	policyV1 "github.com/jrasell/chemtrail/pkg/server/endpoints/v1/policy"
 	scaleV1 "github.com/jrasell/chemtrail/pkg/server/endpoints/v1/scale"
 	systemV1 "github.com/jrasell/chemtrail/pkg/server/endpoints/v1/system"
@@ -10,20 +12,23 @@ import (
 )
 
 type routes struct {
-	policy *policyV1.Server
-	scale  *scaleV1.Server
-	system *systemV1.Server
+	evaluation *evaluationV1.Server
+	node       *nodeV1.Server
+	policy     *policyV1.Server
+	scale      *scaleV1.Server
+	system     *systemV1.Server
 }
 
 func (h *HTTPServer) setupRoutes() *router.RouteTable {
 	h.logger.Debug().Msg("setting up HTTP server routes")
-	return &router.RouteTable{h.setupSystemRoutes(), h.setupScaleRoutes(), h.setupPolicyRoutes()}
+	return &router.RouteTable{h.setupSystemRoutes(), h.setupScaleRoutes(), h.setupPolicyRoutes(),
+		h.setupEvaluationRoutes(), h.setupNodeRoutes()}
 }
 
 func (h *HTTPServer) setupSystemRoutes() []router.Route {
 	h.logger.Debug().Msg("setting up HTTP server system routes")
 
-	h.routes.system = systemV1.NewServer(h.logger, h.telemetry)
+	h.routes.system = systemV1.NewServer(h.logger, h.telemetry, h.cfg.Autoscale.Enabled, h.pauseState, h.elector)
 
 	return router.Routes{
 		router.Route{
@@ -38,6 +43,30 @@ func (h *HTTPServer) setupSystemRoutes() []router.Route {
 			Pattern: routeGetSystemMetricsPattern,
 			Handler: h.routes.system.GetMetrics,
 		},
+		router.Route{
+			Name:    routeGetSystemLeaderName,
+			Method:  http.MethodGet,
+			Pattern: routeGetSystemLeaderPattern,
+			Handler: h.routes.system.GetLeader,
+		},
+		router.Route{
+			Name:    routeGetAutoscalerStatusName,
+			Method:  http.MethodGet,
+			Pattern: routeGetAutoscalerStatusPattern,
+			Handler: h.routes.system.GetAutoscalerStatus,
+		},
+		router.Route{
+			Name:    routePauseAutoscalerName,
+			Method:  http.MethodPost,
+			Pattern: routePauseAutoscalerPattern,
+			Handler: h.routes.system.PauseAutoscaler,
+		},
+		router.Route{
+			Name:    routeResumeAutoscalerName,
+			Method:  http.MethodPost,
+			Pattern: routeResumeAutoscalerPattern,
+			Handler: h.routes.system.ResumeAutoscaler,
+		},
 	}
 }
 
@@ -47,6 +76,7 @@ func (h *HTTPServer) setupScaleRoutes() []router.Route {
 	h.routes.scale = &scaleV1.Server{
 		Logger:        h.logger.With().Str("component", "endpoint-scale").Logger(),
 		Scale:         h.scaler,
+		LeaderContext: h.leaderContext,
 		PolicyBackend: h.policyState,
 		ScaleBackend:  h.scaleState,
 	}
@@ -56,13 +86,13 @@ func (h *HTTPServer) setupScaleRoutes() []router.Route {
 			Name:    routePostScaleInName,
 			Method:  http.MethodPost,
 			Pattern: routeScaleInPattern,
-			Handler: h.routes.scale.PostScaleIn,
+			Handler: h.forwardToLeader(h.routes.scale.PostScaleIn),
 		},
 		router.Route{
 			Name:    routeScaleOutName,
 			Method:  http.MethodPost,
 			Pattern: routeScaleOutPattern,
-			Handler: h.routes.scale.PostScaleOut,
+			Handler: h.forwardToLeader(h.routes.scale.PostScaleOut),
 		},
 		router.Route{
 			Name:    routeGetScaleStatusName,
@@ -82,7 +112,7 @@ func (h *HTTPServer) setupScaleRoutes() []router.Route {
 func (h *HTTPServer) setupPolicyRoutes() []router.Route {
 	h.logger.Debug().Msg("setting up HTTP server policy routes")
 
-	h.routes.policy = policyV1.NewServer(h.logger, h.policyState)
+	h.routes.policy = policyV1.NewServer(h.logger, h.policyState, h.autoscaler)
 
 	return router.Routes{
 		router.Route{
@@ -97,17 +127,71 @@ func (h *HTTPServer) setupPolicyRoutes() []router.Route {
 			Pattern: routeGetPolicyPattern,
 			Handler: h.routes.policy.GetPolicy,
 		},
+		router.Route{
+			Name:    routeGetPolicySchedulesName,
+			Method:  http.MethodGet,
+			Pattern: routeGetPolicySchedulesPattern,
+			Handler: h.routes.policy.GetPolicySchedules,
+		},
+		router.Route{
+			Name:    routeEvaluatePolicyName,
+			Method:  http.MethodPost,
+			Pattern: routeEvaluatePolicyPattern,
+			Handler: h.routes.policy.EvaluatePolicy,
+		},
 		router.Route{
 			Name:    routePutPolicyName,
 			Method:  http.MethodPut,
 			Pattern: routePutPolicyPattern,
-			Handler: h.routes.policy.PutPolicy,
+			Handler: h.forwardToLeader(h.routes.policy.PutPolicy),
 		},
 		router.Route{
 			Name:    routeDeletePolicyName,
 			Method:  http.MethodDelete,
 			Pattern: routeDeletePolicyPattern,
-			Handler: h.routes.policy.DeletePolicy,
+			Handler: h.forwardToLeader(h.routes.policy.DeletePolicy),
+		},
+	}
+}
+
+func (h *HTTPServer) setupEvaluationRoutes() []router.Route {
+	h.logger.Debug().Msg("setting up HTTP server evaluation routes")
+
+	h.routes.evaluation = evaluationV1.NewServer(h.logger, h.scaleState)
+
+	return router.Routes{
+		router.Route{
+			Name:    routeGetEvaluationsName,
+			Method:  http.MethodGet,
+			Pattern: routeGetEvaluationsPattern,
+			Handler: h.routes.evaluation.GetEvaluations,
+		},
+		router.Route{
+			Name:    routeGetClassEvaluationsName,
+			Method:  http.MethodGet,
+			Pattern: routeGetClassEvaluationsPattern,
+			Handler: h.routes.evaluation.GetClassEvaluations,
+		},
+	}
+}
+
+func (h *HTTPServer) setupNodeRoutes() []router.Route {
+	h.logger.Debug().Msg("setting up HTTP server node routes")
+
+	h.routes.node = nodeV1.NewServer(h.logger, h.nodeResourceHandler)
+
+	return router.Routes{
+		router.Route{
+			Name:    routeGetNodesName,
+			Method:  http.MethodGet,
+			Pattern: routeGetNodesPattern,
+			Handler: h.routes.node.GetNodes,
+		},
+		router.Route{
+			Name:    routeGetClassNodesName,
+			Method:  http.MethodGet,
+			Pattern: routeGetClassNodesPattern,
+			Handler: h.routes.node.GetClassNodes,
 		},
 	}
 }
@@ -8,22 +8,32 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"github.com/armon/go-metrics"
 	consulAPI "github.com/hashicorp/consul/api"
 	"github.com/jrasell/chemtrail/pkg/client"
+	"github.com/jrasell/chemtrail/pkg/leader"
 	"github.com/jrasell/chemtrail/pkg/scale"
 	"github.com/jrasell/chemtrail/pkg/scale/auto"
+	"github.com/jrasell/chemtrail/pkg/scale/metric"
+	metricNomad "github.com/jrasell/chemtrail/pkg/scale/metric/nomad"
+	"github.com/jrasell/chemtrail/pkg/scale/metric/prometheus"
 	"github.com/jrasell/chemtrail/pkg/scale/resource"
 	"github.com/jrasell/chemtrail/pkg/server/router"
 	"github.com/jrasell/chemtrail/pkg/state"
+	historyConsul "github.com/jrasell/chemtrail/pkg/state/history/consul"
+	historyMemory "github.com/jrasell/chemtrail/pkg/state/history/memory"
+	pauseConsul "github.com/jrasell/chemtrail/pkg/state/pause/consul"
+	pauseMemory "github.com/jrasell/chemtrail/pkg/state/pause/memory"
 	policyConsul "github.com/jrasell/chemtrail/pkg/state/policy/consul"
 	policyMemory "github.com/jrasell/chemtrail/pkg/state/policy/memory"
 	scaleConsul "github.com/jrasell/chemtrail/pkg/state/scale/consul"
 	scaleMemory "github.com/jrasell/chemtrail/pkg/state/scale/memory"
 	"github.com/jrasell/chemtrail/pkg/watcher"
 	"github.com/jrasell/chemtrail/pkg/watcher/allocs"
+	"github.com/jrasell/chemtrail/pkg/watcher/evals"
 	"github.com/jrasell/chemtrail/pkg/watcher/nodes"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -56,15 +66,35 @@ type HTTPServer struct {
 	// API for changes.
 	allocWatcher watcher.Watcher
 
+	// evalWatcher is an implementation of the watcher interface used to monitor the Nomad
+	// evaluation API for changes.
+	evalWatcher watcher.Watcher
+
 	// nodeResourceHandler is the handle on interacting with the Chemtrail stored node resource
 	// information.
 	nodeResourceHandler resource.Handler
 
-	scaleState  state.ScaleBackend
-	policyState state.PolicyBackend
+	scaleState   state.ScaleBackend
+	policyState  state.PolicyBackend
+	historyState state.CheckHistoryBackend
+	pauseState   state.PauseBackend
 
 	telemetry *metrics.InmemSink
 
+	// elector is the leader election implementation used to decide whether this server runs the
+	// autoscaler and state maintenance processes.
+	elector leader.Elector
+
+	// leaderStopChan is used to stop the processes which are run while this server is the leader.
+	leaderStopChan chan struct{}
+
+	// leaderCtx is cancelled once this server loses leadership. Scaling activities requested via
+	// the API are invoked using it, so that they are aborted in the same way as those triggered by
+	// the autoscaler. leaderLock protects leaderCtx and leaderCancel.
+	leaderCtx    context.Context
+	leaderCancel context.CancelFunc
+	leaderLock   sync.RWMutex
+
 	// gcIsRunning is used to track whether this Chemtrail server is currently running the garbage
 	// collection loop.
 	gcIsRunning bool
@@ -103,12 +133,22 @@ func (h *HTTPServer) Start() error {
 	go h.nodeResourceHandler.RunAllocUpdateHandler()
 	go h.allocWatcher.Run(h.nodeResourceHandler.GetAllocUpdateChan())
 
-	if h.cfg.Autoscale.Enabled {
-		go h.autoscaler.Run()
+	// Start the internal evaluation update processor.
+	go h.nodeResourceHandler.RunEvalUpdateHandler()
+	go h.evalWatcher.Run(h.nodeResourceHandler.GetEvalUpdateChan())
+
+	// Start the class resource sampler, used to provide time windowed resource aggregation.
+	go h.nodeResourceHandler.RunResourceSampler()
+
+	// Start the resource usage collector if the operator has enabled it, used to provide the
+	// actual resource usage of allocations within each class.
+	if h.cfg.Autoscale.UsageEnabled {
+		go h.nodeResourceHandler.RunUsageCollector()
 	}
 
-	// Trigger the garbage collection periodic loop.
-	go h.runGarbageCollectionLoop()
+	// Campaign for leadership. Only the leader runs the autoscaler and state garbage collection,
+	// so that multiple servers do not act on the same classes.
+	go h.elector.Run(h.startLeaderProcesses, h.stopLeaderProcesses)
 
 	h.handleSignals()
 	return nil
@@ -128,7 +168,16 @@ func (h *HTTPServer) setup() error {
 	// Setup the state backend.
 	h.setupStateBackend()
 
-	h.nodeResourceHandler = resource.NewHandler(h.logger, h.nomad)
+	// Setup leader election, which uses the same Consul path as the state backend.
+	h.setupLeaderElection()
+
+	h.nodeResourceHandler = resource.NewHandler(&resource.HandlerConfig{
+		Logger:          h.logger,
+		Nomad:           h.nomad,
+		SampleInterval:  h.cfg.Autoscale.SampleInterval,
+		SampleRetention: h.cfg.Autoscale.SampleRetention,
+		UsageInterval:   h.cfg.Autoscale.UsageInterval,
+	})
 
 	h.scaler = scale.NewScaleBackend(&scale.BackendConfig{
 		Provider:      h.cfg.Provider,
@@ -141,22 +190,29 @@ func (h *HTTPServer) setup() error {
 
 	h.nodeWatcher = nodes.NewWatcher(h.logger, h.nomad.Client)
 	h.allocWatcher = allocs.NewWatcher(h.logger, h.nomad.Client)
-
-	if h.cfg.Autoscale.Enabled {
-		as, err := auto.NewAutoScaler(&auto.Config{
-			Nomad:    h.nomad,
-			Logger:   h.logger,
-			Policy:   h.policyState,
-			Resource: h.nodeResourceHandler,
-			Scale:    h.scaler,
-			Interval: h.cfg.Autoscale.Interval,
-			Threads:  h.cfg.Autoscale.Threads,
-		})
-		if err != nil {
-			return nil
-		}
-		h.autoscaler = as
+	h.evalWatcher = evals.NewWatcher(h.logger, h.nomad.Client)
+
+	// The autoscaler is always setup, as it is used to perform dry-run policy evaluations. It is
+	// only run if the operator has enabled it.
+	as, err := auto.NewAutoScaler(&auto.Config{
+		Nomad:    h.nomad,
+		Logger:   h.logger,
+		Policy:   h.policyState,
+		State:    h.scaleState,
+		History:  h.historyState,
+		Pause:    h.pauseState,
+		Resource: h.nodeResourceHandler,
+		Scale:    h.scaler,
+		Interval: h.cfg.Autoscale.Interval,
+		Threads:  h.cfg.Autoscale.Threads,
+		Jitter:   h.cfg.Autoscale.Jitter,
+
+		MetricSources: h.setupMetricSources(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to setup autoscaler")
 	}
+	h.autoscaler = as
 
 	// Setup telemetry based on the config passed by the operator.
 	if err := h.setupTelemetry(); err != nil {
@@ -241,10 +297,14 @@ func (h *HTTPServer) setupStateBackend() {
 		h.logger.Debug().Msg("setting up Consul storage backend")
 		h.policyState = policyConsul.NewPolicyBackend(h.cfg.Storage.ConsulPath, h.consul)
 		h.scaleState = scaleConsul.NewScaleBackend(h.logger, h.cfg.Storage.ConsulPath, h.consul)
+		h.historyState = historyConsul.NewCheckHistoryBackend(h.cfg.Storage.ConsulPath, h.consul)
+		h.pauseState = pauseConsul.NewPauseBackend(h.cfg.Storage.ConsulPath, h.consul)
 	} else {
 		h.logger.Debug().Msg("setting up in-memory storage backend")
 		h.policyState = policyMemory.NewPolicyBackend()
 		h.scaleState = scaleMemory.NewScaleStateBackend()
+		h.historyState = historyMemory.NewCheckHistoryBackend()
+		h.pauseState = pauseMemory.NewPauseBackend()
 	}
 }
 
@@ -271,12 +331,9 @@ func (h *HTTPServer) Stop() error {
 
 	h.nodeResourceHandler.StopUpdateHandlers()
 
-	// If the autoscaler is running, stop this. It is important that a Chemtrail server is given
-	// time to exit cleanly as this call can take a number of seconds to complete while we
-	// gracefully wait for all in-flight worker threads to finish.
-	if h.autoscaler != nil && h.autoscaler.IsRunning() {
-		h.autoscaler.Stop()
-	}
+	// Stop campaigning for leadership, which stops any leader processes and releases leadership
+	// so that another server can take over.
+	h.elector.Stop()
 
 	// Send a signal to the HTTPServer stopChan instructing sub-process to stop.
 	close(h.stopChan)
@@ -305,3 +362,18 @@ func (h *HTTPServer) handleSignals() {
 		}
 	}
 }
+
+// setupMetricSources builds the metric sources available to policy checks. The Nomad source is
+// always available, while external sources are only configured when the operator has supplied
+// their address.
+func (h *HTTPServer) setupMetricSources() map[state.MetricSource]metric.Source {
+	sources := map[state.MetricSource]metric.Source{
+		state.MetricSourceNomad: metricNomad.NewNomadSource(h.nodeResourceHandler),
+	}
+
+	if h.cfg.MetricSource.PrometheusAddr != "" {
+		sources[state.MetricSourcePrometheus] = prometheus.NewPrometheusSource(h.logger, h.cfg.MetricSource.PrometheusAddr)
+		h.logger.Debug().Msg("successfully setup Prometheus metric source")
+	}
+	return sources
+}
@@ -0,0 +1,99 @@
+package state
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultDrainDeadline is the default time period in seconds allowed for allocations to migrate
+// off a node being removed, before they are forcefully stopped.
+const defaultDrainDeadline = 300
+
+// DrainConfig controls how Nomad drains a node which is being removed from the cluster during a
+// scale in activity.
+type DrainConfig struct {
+
+	// Deadline is the time period in seconds allowed for allocations to migrate off the node,
+	// after which any remaining allocations are forcefully stopped. Defaults to 300.
+	Deadline int `json:"Deadline"`
+
+	// IgnoreSystemJobs stops the allocations of system jobs from being drained, allowing them to
+	// continue running until the node is terminated.
+	IgnoreSystemJobs bool `json:"IgnoreSystemJobs"`
+
+	// Force stops all allocations on the node immediately, rather than waiting for them to
+	// migrate. Cannot be used alongside Deadline.
+	Force bool `json:"Force"`
+
+	// Timeout is the time period in seconds after which the drain is considered failed, failing
+	// the scaling activity without terminating the node. Defaults to 0, which waits for the drain
+	// to complete.
+	Timeout int `json:"Timeout"`
+
+	// BatchSize is the maximum number of nodes drained in parallel when a scale in activity
+	// removes multiple nodes. Each batch must finish draining before the next batch starts.
+	// Defaults to 0, which drains all nodes in parallel.
+	BatchSize int `json:"BatchSize"`
+}
+
+// GetDeadline returns the drain deadline, applying the default if the config or deadline has not
+// been set. A forced drain returns a negative deadline, matching the Nomad drain spec.
+func (d *DrainConfig) GetDeadline() time.Duration {
+	switch {
+	case d != nil && d.Force:
+		return -1 * time.Second
+	case d == nil || d.Deadline == 0:
+		return defaultDrainDeadline * time.Second
+	default:
+		return time.Duration(d.Deadline) * time.Second
+	}
+}
+
+// GetTimeout returns the drain timeout. A value of 0 indicates no timeout.
+func (d *DrainConfig) GetTimeout() time.Duration {
+	if d == nil {
+		return 0
+	}
+	return time.Duration(d.Timeout) * time.Second
+}
+
+// GetIgnoreSystemJobs returns whether the allocations of system jobs are ignored by the drain.
+func (d *DrainConfig) GetIgnoreSystemJobs() bool {
+	return d != nil && d.IgnoreSystemJobs
+}
+
+// GetBatchSize returns the number of nodes to drain in parallel when removing the passed number of
+// nodes.
+func (d *DrainConfig) GetBatchSize(nodes int) int {
+	if d == nil || d.BatchSize < 1 || d.BatchSize > nodes {
+		return nodes
+	}
+	return d.BatchSize
+}
+
+// Validate checks the DrainConfig contains sensible durations and batch size.
+func (d *DrainConfig) Validate() error {
+	if d == nil {
+		return nil
+	}
+
+	if d.Deadline < 0 || d.Timeout < 0 {
+		return errors.New("Drain Deadline and Timeout must not be negative")
+	}
+
+	if d.BatchSize < 0 {
+		return errors.New("Drain BatchSize must not be negative")
+	}
+
+	if d.Force && d.Deadline > 0 {
+		return errors.New("Drain Force and Deadline cannot both be set")
+	}
+
+	// A timeout shorter than the deadline would always fail the activity before Nomad forces the
+	// remaining allocations off the node.
+	if d.Timeout > 0 && d.GetTimeout() < d.GetDeadline() {
+		return errors.New("Drain Timeout must not be less than the Deadline")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		inputDrain  *DrainConfig
+		expectError bool
+		name        string
+	}{
+		{
+			inputDrain:  nil,
+			expectError: false,
+			name:        "nil drain config",
+		},
+		{
+			inputDrain:  &DrainConfig{Deadline: 7200, IgnoreSystemJobs: true, Timeout: 9000},
+			expectError: false,
+			name:        "valid long running drain",
+		},
+		{
+			inputDrain:  &DrainConfig{Force: true, Timeout: 60},
+			expectError: false,
+			name:        "valid forced drain",
+		},
+		{
+			inputDrain:  &DrainConfig{Deadline: -1},
+			expectError: true,
+			name:        "negative deadline",
+		},
+		{
+			inputDrain:  &DrainConfig{Force: true, Deadline: 600},
+			expectError: true,
+			name:        "force and deadline",
+		},
+		{
+			inputDrain:  &DrainConfig{Timeout: 60},
+			expectError: true,
+			name:        "timeout less than default deadline",
+		},
+		{
+			inputDrain:  &DrainConfig{BatchSize: -1},
+			expectError: true,
+			name:        "negative batch size",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputDrain.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestDrainConfig_GetDeadline(t *testing.T) {
+	testCases := []struct {
+		inputDrain     *DrainConfig
+		expectedOutput time.Duration
+		name           string
+	}{
+		{
+			inputDrain:     nil,
+			expectedOutput: 5 * time.Minute,
+			name:           "nil drain config",
+		},
+		{
+			inputDrain:     &DrainConfig{Deadline: 7200},
+			expectedOutput: 2 * time.Hour,
+			name:           "configured deadline",
+		},
+		{
+			inputDrain:     &DrainConfig{Force: true},
+			expectedOutput: -1 * time.Second,
+			name:           "forced drain",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, tc.inputDrain.GetDeadline(), tc.name)
+	}
+}
+
+func TestDrainConfig_GetBatchSize(t *testing.T) {
+	testCases := []struct {
+		inputDrain     *DrainConfig
+		inputNodes     int
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputDrain:     nil,
+			inputNodes:     5,
+			expectedOutput: 5,
+			name:           "nil drain config",
+		},
+		{
+			inputDrain:     &DrainConfig{BatchSize: 2},
+			inputNodes:     5,
+			expectedOutput: 2,
+			name:           "configured batch size",
+		},
+		{
+			inputDrain:     &DrainConfig{BatchSize: 10},
+			inputNodes:     5,
+			expectedOutput: 5,
+			name:           "batch size larger than node count",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, tc.inputDrain.GetBatchSize(tc.inputNodes), tc.name)
+	}
+}
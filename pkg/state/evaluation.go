@@ -0,0 +1,149 @@
+package state
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// EvaluationRecordRetention is the maximum number of evaluation records retained per class.
+	// Once reached, writing a new record removes the oldest record of the class. Records older
+	// than the GarbageCollectionThreshold are also removed during state garbage collection.
+	EvaluationRecordRetention = 50
+)
+
+// EvaluationRecord details the outcome of a single autoscaler evaluation of a class, including the
+// values read by each check and why the evaluation was acted on or skipped.
+type EvaluationRecord struct {
+
+	// ID is the unique identifier of the evaluation.
+	ID uuid.UUID
+
+	// Class is the Nomad client class which was evaluated.
+	Class string
+
+	// Timestamp is the UnixNano timestamp at which the evaluation started.
+	Timestamp int64
+
+	// Strategy is the decision strategy used by the policy during the evaluation.
+	Strategy PolicyStrategy
+
+	// Checks contains the result of each check or target run during the evaluation, keyed by the
+	// check or target name.
+	Checks map[string]*CheckRecord
+
+	// Schedule is the name of the schedule window which was active during the evaluation, if any.
+	Schedule string
+
+	// Direction is the final scaling direction decided by the evaluation.
+	Direction ScaleDirection
+
+	// Count is the number of nodes the decision requested to add or remove. A value of 0 uses the
+	// policy count for the direction.
+	Count int
+
+	// Outcome describes whether the evaluation triggered scaling or why it did not.
+	Outcome EvaluationOutcome
+
+	// Reason is a short machine readable reason for the outcome, such as the skip reason.
+	Reason string
+
+	// Detail is a human readable explanation of the outcome.
+	Detail string
+
+	// ActivityID is the ID of the scaling activity triggered by the evaluation, if any.
+	ActivityID string
+}
+
+// CheckRecord is the result of an individual check or target within an evaluation.
+type CheckRecord struct {
+
+	// Source is the metric source the value was read from.
+	Source string
+
+	// Resource is the Nomad resource evaluated when using the Nomad source.
+	Resource ScaleResource
+
+	// Value is the actual value read by the check.
+	Value float64
+
+	// Threshold is the value compared against, which is the target value for targets.
+	Threshold float64
+
+	// ComparisonOperator is the operator used to compare the value against the threshold.
+	ComparisonOperator ComparisonOperator
+
+	// Action is the scaling action of the check.
+	Action ComparisonAction
+
+	// Breached indicates whether the value breached the threshold during this evaluation.
+	Breached bool
+
+	// Fired indicates whether the check reached its required breach count and therefore
+	// contributed to the decision. For targets, this indicates a change to the class size was
+	// required.
+	Fired bool
+
+	// DesiredCount is the class size calculated by a target. This is not used by checks.
+	DesiredCount int
+}
+
+// NewEvaluationRecord returns a new EvaluationRecord for the class.
+func NewEvaluationRecord(id uuid.UUID, class string, ts int64) *EvaluationRecord {
+	return &EvaluationRecord{
+		ID:        id,
+		Class:     class,
+		Timestamp: ts,
+		Checks:    make(map[string]*CheckRecord),
+		Direction: ScaleDirectionNone,
+	}
+}
+
+// SetOutcome updates the outcome of the evaluation along with the reason and detail.
+func (e *EvaluationRecord) SetOutcome(outcome EvaluationOutcome, reason, detail string) {
+	e.Outcome = outcome
+	e.Reason = reason
+	e.Detail = detail
+}
+
+// EvaluationOutcome describes the result of an autoscaler evaluation.
+type EvaluationOutcome string
+
+// String returns the string form of the EvaluationOutcome.
+func (e EvaluationOutcome) String() string { return string(e) }
+
+const (
+	// EvaluationOutcomeScaling indicates the evaluation triggered a scaling activity.
+	EvaluationOutcomeScaling EvaluationOutcome = "scaling"
+
+	// EvaluationOutcomeNoAction indicates the evaluation found no scaling was required.
+	EvaluationOutcomeNoAction EvaluationOutcome = "no-action"
+
+	// EvaluationOutcomeSkipped indicates the evaluation was not acted upon, such as when the
+	// class is within a cooldown period or the policy is disabled.
+	EvaluationOutcomeSkipped EvaluationOutcome = "skipped"
+
+	// EvaluationOutcomeFailed indicates the evaluation was unable to complete due to an error.
+	EvaluationOutcomeFailed EvaluationOutcome = "failed"
+)
+
+// AddEvaluationRecord adds the record to the passed class records, which are ordered newest
+// first, and trims the result to the EvaluationRecordRetention.
+func AddEvaluationRecord(records []*EvaluationRecord, record *EvaluationRecord) []*EvaluationRecord {
+	out := append([]*EvaluationRecord{record}, records...)
+	if len(out) > EvaluationRecordRetention {
+		out = out[:EvaluationRecordRetention]
+	}
+	return out
+}
+
+// PruneEvaluationRecords removes the records with a timestamp older than the passed UnixNano
+// threshold.
+func PruneEvaluationRecords(records []*EvaluationRecord, threshold int64) []*EvaluationRecord {
+	out := records[:0]
+	for _, r := range records {
+		if r.Timestamp >= threshold {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEvaluationRecord(t *testing.T) {
+	var records []*EvaluationRecord
+
+	for i := 0; i < EvaluationRecordRetention+5; i++ {
+		records = AddEvaluationRecord(records, &EvaluationRecord{Timestamp: int64(i)})
+	}
+
+	assert.Len(t, records, EvaluationRecordRetention)
+	assert.Equal(t, int64(EvaluationRecordRetention+4), records[0].Timestamp, "newest record first")
+	assert.Equal(t, int64(5), records[len(records)-1].Timestamp, "oldest records trimmed")
+}
+
+func TestPruneEvaluationRecords(t *testing.T) {
+	testCases := []struct {
+		inputRecords   []*EvaluationRecord
+		inputThreshold int64
+		expectedOutput []*EvaluationRecord
+		name           string
+	}{
+		{
+			inputRecords:   []*EvaluationRecord{{Timestamp: 30}, {Timestamp: 20}, {Timestamp: 10}},
+			inputThreshold: 20,
+			expectedOutput: []*EvaluationRecord{{Timestamp: 30}, {Timestamp: 20}},
+			name:           "records older than threshold removed",
+		},
+		{
+			inputRecords:   []*EvaluationRecord{{Timestamp: 10}},
+			inputThreshold: 20,
+			expectedOutput: []*EvaluationRecord{},
+			name:           "all records removed",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := PruneEvaluationRecords(tc.inputRecords, tc.inputThreshold)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -0,0 +1,189 @@
+package state
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// CheckExpression is a parsed boolean expression which combines the outcomes of named policy
+// checks. Expressions support the AND, OR and NOT operators, along with parentheses for grouping.
+// Operators are case-insensitive and bind in the order NOT, AND, OR.
+type CheckExpression interface {
+
+	// Evaluate returns the result of the expression given the outcome of each check, keyed by the
+	// check name. Checks which are not present within the map evaluate as false.
+	Evaluate(results map[string]bool) bool
+
+	// Checks returns the names of the checks referenced within the expression.
+	Checks() []string
+}
+
+type exprAnd struct{ left, right CheckExpression }
+
+func (e *exprAnd) Evaluate(r map[string]bool) bool { return e.left.Evaluate(r) && e.right.Evaluate(r) }
+func (e *exprAnd) Checks() []string                { return append(e.left.Checks(), e.right.Checks()...) }
+
+type exprOr struct{ left, right CheckExpression }
+
+func (e *exprOr) Evaluate(r map[string]bool) bool { return e.left.Evaluate(r) || e.right.Evaluate(r) }
+func (e *exprOr) Checks() []string                { return append(e.left.Checks(), e.right.Checks()...) }
+
+type exprNot struct{ expr CheckExpression }
+
+func (e *exprNot) Evaluate(r map[string]bool) bool { return !e.expr.Evaluate(r) }
+func (e *exprNot) Checks() []string                { return e.expr.Checks() }
+
+type exprCheck struct{ name string }
+
+func (e *exprCheck) Evaluate(r map[string]bool) bool { return r[e.name] }
+func (e *exprCheck) Checks() []string                { return []string{e.name} }
+
+const (
+	exprTokenAnd    = "AND"
+	exprTokenOr     = "OR"
+	exprTokenNot    = "NOT"
+	exprTokenLParen = "("
+	exprTokenRParen = ")"
+)
+
+// ParseCheckExpression parses the string form of a check expression, such as
+// "cpu-in AND (memory-in OR NOT queued-out)". Check names may contain any characters other than
+// whitespace and parentheses.
+func ParseCheckExpression(s string) (CheckExpression, error) {
+	p := exprParser{tokens: tokenizeExpression(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("expression is empty")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, errors.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// tokenizeExpression splits the expression into parentheses and whitespace separated words.
+// Operator keywords are normalised to upper case.
+func tokenizeExpression(s string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := word.String()
+		switch upper := strings.ToUpper(w); upper {
+		case exprTokenAnd, exprTokenOr, exprTokenNot:
+			w = upper
+		}
+		tokens = append(tokens, w)
+		word.Reset()
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// exprParser is a recursive descent parser over the expression tokens.
+type exprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) parseOr() (CheckExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == exprTokenOr {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (CheckExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == exprTokenAnd {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (CheckExpression, error) {
+	if p.peek() == exprTokenNot {
+		p.pos++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNot{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (CheckExpression, error) {
+	tok := p.peek()
+
+	switch tok {
+	case "":
+		return nil, errors.New("unexpected end of expression")
+	case exprTokenLParen:
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != exprTokenRParen {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case exprTokenRParen, exprTokenAnd, exprTokenOr:
+		return nil, errors.Errorf("unexpected token %q", tok)
+	default:
+		p.pos++
+		return &exprCheck{name: tok}, nil
+	}
+}
@@ -0,0 +1,143 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckExpression(t *testing.T) {
+	testCases := []struct {
+		inputExpression string
+		inputResults    map[string]bool
+		expectedChecks  []string
+		expectedResult  bool
+		expectError     bool
+		name            string
+	}{
+		{
+			inputExpression: "cpu-in AND memory-in",
+			inputResults:    map[string]bool{"cpu-in": true, "memory-in": true},
+			expectedChecks:  []string{"cpu-in", "memory-in"},
+			expectedResult:  true,
+			name:            "and all fired",
+		},
+		{
+			inputExpression: "cpu-in and memory-in",
+			inputResults:    map[string]bool{"cpu-in": true},
+			expectedChecks:  []string{"cpu-in", "memory-in"},
+			expectedResult:  false,
+			name:            "lower case and not all fired",
+		},
+		{
+			inputExpression: "cpu-in OR memory-in AND disk-in",
+			inputResults:    map[string]bool{"cpu-in": true},
+			expectedChecks:  []string{"cpu-in", "memory-in", "disk-in"},
+			expectedResult:  true,
+			name:            "and binds tighter than or",
+		},
+		{
+			inputExpression: "(cpu-in OR memory-in) AND disk-in",
+			inputResults:    map[string]bool{"cpu-in": true},
+			expectedChecks:  []string{"cpu-in", "memory-in", "disk-in"},
+			expectedResult:  false,
+			name:            "parentheses grouping",
+		},
+		{
+			inputExpression: "cpu-in AND NOT queued-in",
+			inputResults:    map[string]bool{"cpu-in": true},
+			expectedChecks:  []string{"cpu-in", "queued-in"},
+			expectedResult:  true,
+			name:            "not operator",
+		},
+		{
+			inputExpression: "",
+			expectError:     true,
+			name:            "empty expression",
+		},
+		{
+			inputExpression: "cpu-in AND",
+			expectError:     true,
+			name:            "missing operand",
+		},
+		{
+			inputExpression: "(cpu-in OR memory-in",
+			expectError:     true,
+			name:            "missing closing parenthesis",
+		},
+		{
+			inputExpression: "cpu-in memory-in",
+			expectError:     true,
+			name:            "missing operator",
+		},
+	}
+
+	for _, tc := range testCases {
+		expr, err := ParseCheckExpression(tc.inputExpression)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedChecks, expr.Checks(), tc.name)
+		assert.Equal(t, tc.expectedResult, expr.Evaluate(tc.inputResults), tc.name)
+	}
+}
+
+func TestClientScalingPolicy_validateExpression(t *testing.T) {
+	pol := ClientScalingPolicy{
+		Checks: map[string]*PolicyCheck{
+			"cpu-in":    {Action: ActionScaleIn, Enabled: true},
+			"memory-in": {Action: ActionScaleIn, Enabled: true},
+			"disk-in":   {Action: ActionScaleIn},
+			"cpu-out":   {Action: ActionScaleOut, Enabled: true},
+		},
+	}
+
+	testCases := []struct {
+		inputAction     ComparisonAction
+		inputExpression string
+		expectError     bool
+		name            string
+	}{
+		{
+			inputAction:     ActionScaleIn,
+			inputExpression: "cpu-in AND memory-in",
+			expectError:     false,
+			name:            "valid expression",
+		},
+		{
+			inputAction:     ActionScaleIn,
+			inputExpression: "cpu-in AND network-in",
+			expectError:     true,
+			name:            "unknown check",
+		},
+		{
+			inputAction:     ActionScaleIn,
+			inputExpression: "cpu-in AND NOT disk-in",
+			expectError:     true,
+			name:            "disabled check",
+		},
+		{
+			inputAction:     ActionScaleIn,
+			inputExpression: "cpu-in AND cpu-out",
+			expectError:     true,
+			name:            "check action mismatch",
+		},
+		{
+			inputAction:     "scale-sideways",
+			inputExpression: "cpu-in",
+			expectError:     true,
+			name:            "invalid action",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := pol.validateExpression(tc.inputAction, tc.inputExpression)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
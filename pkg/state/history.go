@@ -0,0 +1,74 @@
+package state
+
+// CheckHistoryBackend is the interface which storage providers must implement in order to store
+// the rolling history of scaling policy check outcomes.
+type CheckHistoryBackend interface {
+
+	// GetCheckHistory returns the stored check history for the class. If no history is held for
+	// the class, nil is returned.
+	GetCheckHistory(class string) (*CheckHistory, error)
+
+	// PutCheckHistory is used to write the check history of a class. Updates should overwrite any
+	// stored history for the class.
+	PutCheckHistory(history *CheckHistory) error
+
+	// DeleteCheckHistory is used to delete the check history of a class if it exists within the
+	// backend storage.
+	DeleteCheckHistory(class string) error
+}
+
+// CheckHistory tracks the outcomes of the most recent evaluations of each check within a class
+// scaling policy. It allows the autoscaler to require a number of breaches within a window of
+// evaluations before a check is considered to have fired.
+type CheckHistory struct {
+
+	// Class is the Nomad client class to which the history belongs.
+	Class string `json:"Class"`
+
+	// Checks is keyed by the check name and contains the outcome of the most recent evaluations
+	// ordered oldest to newest. A true value indicates the check threshold was breached.
+	Checks map[string][]bool `json:"Checks"`
+}
+
+// NewCheckHistory returns an empty CheckHistory for the class.
+func NewCheckHistory(class string) *CheckHistory {
+	return &CheckHistory{Class: class, Checks: make(map[string][]bool)}
+}
+
+// Record appends the outcome of an evaluation to the history of the named check, trimming the
+// history so it contains at most size entries.
+func (ch *CheckHistory) Record(check string, breached bool, size int) {
+	if size < 1 {
+		size = 1
+	}
+
+	outcomes := append(ch.Checks[check], breached)
+	if len(outcomes) > size {
+		outcomes = outcomes[len(outcomes)-size:]
+	}
+	ch.Checks[check] = outcomes
+}
+
+// Breaches returns the number of evaluations within the history of the named check which
+// breached the threshold.
+func (ch *CheckHistory) Breaches(check string) int {
+	var count int
+
+	for _, breached := range ch.Checks[check] {
+		if breached {
+			count++
+		}
+	}
+	return count
+}
+
+// Prune removes the history of any checks which are not present within the passed map of check
+// names. This ensures the history does not hold stale entries for checks which have been removed
+// from, or disabled within, the scaling policy.
+func (ch *CheckHistory) Prune(active map[string]bool) {
+	for name := range ch.Checks {
+		if !active[name] {
+			delete(ch.Checks, name)
+		}
+	}
+}
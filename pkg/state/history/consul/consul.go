@@ -0,0 +1,76 @@
+package consul
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// baseHistoryKVPath is the Consul path suffix added to the CLI param which identifies where check
+// history state is stored.
+const baseHistoryKVPath = "state/history/"
+
+// CheckHistoryBackend is the Consul implementation of the state.CheckHistoryBackend interface.
+type CheckHistoryBackend struct {
+	path string
+	kv   *api.KV
+}
+
+// NewCheckHistoryBackend returns the Consul implementation of the state.CheckHistoryBackend
+// interface.
+func NewCheckHistoryBackend(path string, client *api.Client) state.CheckHistoryBackend {
+	return &CheckHistoryBackend{
+		path: path + baseHistoryKVPath,
+		kv:   client.KV(),
+	}
+}
+
+// GetCheckHistory satisfies the GetCheckHistory function on the state.CheckHistoryBackend
+// interface.
+func (c *CheckHistoryBackend) GetCheckHistory(class string) (*state.CheckHistory, error) {
+	kv, _, err := c.kv.Get(c.path+class, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	out := &state.CheckHistory{}
+
+	if err := json.Unmarshal(kv.Value, out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+
+	if out.Checks == nil {
+		out.Checks = make(map[string][]bool)
+	}
+	return out, nil
+}
+
+// PutCheckHistory satisfies the PutCheckHistory function on the state.CheckHistoryBackend
+// interface.
+func (c *CheckHistoryBackend) PutCheckHistory(history *state.CheckHistory) error {
+	marshal, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	pair := &api.KVPair{
+		Key:   c.path + history.Class,
+		Value: marshal,
+	}
+
+	_, err = c.kv.Put(pair, nil)
+	return err
+}
+
+// DeleteCheckHistory satisfies the DeleteCheckHistory function on the state.CheckHistoryBackend
+// interface.
+func (c *CheckHistoryBackend) DeleteCheckHistory(class string) error {
+	_, err := c.kv.Delete(c.path+class, nil)
+	return err
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// CheckHistoryBackend is the in-memory implementation of the state.CheckHistoryBackend interface.
+type CheckHistoryBackend struct {
+	history map[string]*state.CheckHistory
+	sync.RWMutex
+}
+
+// NewCheckHistoryBackend returns the in-memory implementation of the state.CheckHistoryBackend
+// interface.
+func NewCheckHistoryBackend() state.CheckHistoryBackend {
+	return &CheckHistoryBackend{history: make(map[string]*state.CheckHistory)}
+}
+
+// GetCheckHistory satisfies the GetCheckHistory function on the state.CheckHistoryBackend
+// interface.
+func (c *CheckHistoryBackend) GetCheckHistory(class string) (*state.CheckHistory, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	stored, ok := c.history[class]
+	if !ok {
+		return nil, nil
+	}
+
+	// Return a copy of the stored history so callers cannot modify the stored state without
+	// calling PutCheckHistory.
+	out := state.NewCheckHistory(class)
+	for name, outcomes := range stored.Checks {
+		out.Checks[name] = append([]bool(nil), outcomes...)
+	}
+	return out, nil
+}
+
+// PutCheckHistory satisfies the PutCheckHistory function on the state.CheckHistoryBackend
+// interface.
+func (c *CheckHistoryBackend) PutCheckHistory(history *state.CheckHistory) error {
+	c.Lock()
+	c.history[history.Class] = history
+	c.Unlock()
+	return nil
+}
+
+// DeleteCheckHistory satisfies the DeleteCheckHistory function on the state.CheckHistoryBackend
+// interface.
+func (c *CheckHistoryBackend) DeleteCheckHistory(class string) error {
+	c.Lock()
+	delete(c.history, class)
+	c.Unlock()
+	return nil
+}
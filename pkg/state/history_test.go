@@ -0,0 +1,71 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHistory_Record(t *testing.T) {
+	testCases := []struct {
+		inputHistory   *CheckHistory
+		inputBreached  bool
+		inputSize      int
+		expectedOutput []bool
+		name           string
+	}{
+		{
+			inputHistory:   NewCheckHistory("test"),
+			inputBreached:  true,
+			inputSize:      3,
+			expectedOutput: []bool{true},
+			name:           "first recorded outcome",
+		},
+		{
+			inputHistory:   &CheckHistory{Class: "test", Checks: map[string][]bool{"cpu-out": {true, false, true}}},
+			inputBreached:  false,
+			inputSize:      3,
+			expectedOutput: []bool{false, true, false},
+			name:           "outcome exceeding window size trims oldest",
+		},
+		{
+			inputHistory:   &CheckHistory{Class: "test", Checks: map[string][]bool{"cpu-out": {true, false, true}}},
+			inputBreached:  true,
+			inputSize:      0,
+			expectedOutput: []bool{true},
+			name:           "zero size defaults to single evaluation",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.inputHistory.Record("cpu-out", tc.inputBreached, tc.inputSize)
+		assert.Equal(t, tc.expectedOutput, tc.inputHistory.Checks["cpu-out"], tc.name)
+	}
+}
+
+func TestCheckHistory_Breaches(t *testing.T) {
+	history := &CheckHistory{
+		Class: "test",
+		Checks: map[string][]bool{
+			"cpu-out":    {true, false, true, true},
+			"memory-out": {false, false},
+		},
+	}
+
+	assert.Equal(t, 3, history.Breaches("cpu-out"))
+	assert.Equal(t, 0, history.Breaches("memory-out"))
+	assert.Equal(t, 0, history.Breaches("not-found"))
+}
+
+func TestCheckHistory_Prune(t *testing.T) {
+	history := &CheckHistory{
+		Class: "test",
+		Checks: map[string][]bool{
+			"cpu-out":    {true},
+			"memory-out": {false},
+		},
+	}
+
+	history.Prune(map[string]bool{"cpu-out": true})
+	assert.Equal(t, map[string][]bool{"cpu-out": {true}}, history.Checks)
+}
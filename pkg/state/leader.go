@@ -0,0 +1,12 @@
+package state
+
+// LeaderStatus details the leadership of the Chemtrail servers sharing the storage backend.
+type LeaderStatus struct {
+
+	// Leader is the advertised address of the current leader. An empty string indicates no
+	// leader is currently elected.
+	Leader string `json:"Leader"`
+
+	// IsLeader indicates whether the server which handled the request is the leader.
+	IsLeader bool `json:"IsLeader"`
+}
@@ -0,0 +1,16 @@
+package state
+
+// NodeStatus details a Nomad client node tracked by Chemtrail.
+type NodeStatus struct {
+	ID          string `json:"ID"`
+	Class       string `json:"Class"`
+	Status      string `json:"Status"`
+	Eligibility string `json:"Eligibility"`
+
+	// Allocations is the number of running allocations tracked on the node.
+	Allocations int `json:"Allocations"`
+
+	// Protected indicates whether the node is protected from being removed during scale in
+	// activities via the chemtrail.protected node meta key.
+	Protected bool `json:"Protected"`
+}
@@ -0,0 +1,62 @@
+package state
+
+// PauseBackend is the interface which storage providers must implement in order to store the
+// pause state of the autoscaler. Storing the pause state allows it to persist across restarts of
+// the Chemtrail server.
+type PauseBackend interface {
+
+	// GetPause returns the stored autoscaler pause. If the autoscaler has not been paused, nil is
+	// returned.
+	GetPause() (*AutoscalerPause, error)
+
+	// PutPause is used to write the autoscaler pause. Updates should overwrite any stored pause.
+	PutPause(pause *AutoscalerPause) error
+
+	// DeletePause is used to delete the autoscaler pause if it exists within the backend storage.
+	DeletePause() error
+}
+
+// AutoscalerPause details an operator request to pause the autoscaler. While paused, the
+// autoscaler does not evaluate any scaling policies.
+type AutoscalerPause struct {
+
+	// Reason is the operator supplied reason for pausing the autoscaler.
+	Reason string `json:"Reason"`
+
+	// Timestamp is the UnixNano timestamp at which the autoscaler was paused.
+	Timestamp int64 `json:"Timestamp"`
+
+	// Expiry is the UnixNano timestamp at which the pause ends and the autoscaler automatically
+	// resumes. A value of 0 indicates the pause lasts until the autoscaler is resumed.
+	Expiry int64 `json:"Expiry"`
+}
+
+// IsActive returns whether the pause is in effect at the passed UnixNano timestamp. A nil pause
+// is never active.
+func (p *AutoscalerPause) IsActive(now int64) bool {
+	return p != nil && (p.Expiry == 0 || now < p.Expiry)
+}
+
+// AutoscalerStatus details the current state of the autoscaler.
+type AutoscalerStatus struct {
+
+	// Enabled indicates whether the autoscaler has been enabled by the server configuration.
+	Enabled bool `json:"Enabled"`
+
+	// Paused indicates whether the autoscaler is currently paused.
+	Paused bool `json:"Paused"`
+
+	// Pause contains the details of the active pause, if any.
+	Pause *AutoscalerPause `json:"Pause"`
+}
+
+// AutoscalerPauseRequest is the operator request to pause the autoscaler.
+type AutoscalerPauseRequest struct {
+
+	// Duration is the time period in seconds for which the autoscaler is paused. A value of 0
+	// pauses the autoscaler until it is resumed.
+	Duration int `json:"Duration"`
+
+	// Reason is a free-form description of why the autoscaler is paused.
+	Reason string `json:"Reason"`
+}
@@ -0,0 +1,68 @@
+package consul
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/chemtrail/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// basePauseKVPath is the Consul path suffix added to the CLI param which identifies where the
+// autoscaler pause state is stored.
+const basePauseKVPath = "state/autoscaler/pause"
+
+// PauseBackend is the Consul implementation of the state.PauseBackend interface.
+type PauseBackend struct {
+	path string
+	kv   *api.KV
+}
+
+// NewPauseBackend returns the Consul implementation of the state.PauseBackend interface.
+func NewPauseBackend(path string, client *api.Client) state.PauseBackend {
+	return &PauseBackend{
+		path: path + basePauseKVPath,
+		kv:   client.KV(),
+	}
+}
+
+// GetPause satisfies the GetPause function on the state.PauseBackend interface.
+func (p *PauseBackend) GetPause() (*state.AutoscalerPause, error) {
+	kv, _, err := p.kv.Get(p.path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	out := &state.AutoscalerPause{}
+
+	if err := json.Unmarshal(kv.Value, out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+	return out, nil
+}
+
+// PutPause satisfies the PutPause function on the state.PauseBackend interface.
+func (p *PauseBackend) PutPause(pause *state.AutoscalerPause) error {
+	marshal, err := json.Marshal(pause)
+	if err != nil {
+		return err
+	}
+
+	pair := &api.KVPair{
+		Key:   p.path,
+		Value: marshal,
+	}
+
+	_, err = p.kv.Put(pair, nil)
+	return err
+}
+
+// DeletePause satisfies the DeletePause function on the state.PauseBackend interface.
+func (p *PauseBackend) DeletePause() error {
+	_, err := p.kv.Delete(p.path, nil)
+	return err
+}
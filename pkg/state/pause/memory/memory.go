@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/jrasell/chemtrail/pkg/state"
+)
+
+// PauseBackend is the in-memory implementation of the state.PauseBackend interface.
+type PauseBackend struct {
+	pause *state.AutoscalerPause
+	sync.RWMutex
+}
+
+// NewPauseBackend returns the in-memory implementation of the state.PauseBackend interface.
+func NewPauseBackend() state.PauseBackend {
+	return &PauseBackend{}
+}
+
+// GetPause satisfies the GetPause function on the state.PauseBackend interface.
+func (p *PauseBackend) GetPause() (*state.AutoscalerPause, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	if p.pause == nil {
+		return nil, nil
+	}
+	out := *p.pause
+	return &out, nil
+}
+
+// PutPause satisfies the PutPause function on the state.PauseBackend interface.
+func (p *PauseBackend) PutPause(pause *state.AutoscalerPause) error {
+	p.Lock()
+	p.pause = pause
+	p.Unlock()
+	return nil
+}
+
+// DeletePause satisfies the DeletePause function on the state.PauseBackend interface.
+func (p *PauseBackend) DeletePause() error {
+	p.Lock()
+	p.pause = nil
+	p.Unlock()
+	return nil
+}
@@ -0,0 +1,46 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoscalerPause_IsActive(t *testing.T) {
+	testCases := []struct {
+		inputPause     *AutoscalerPause
+		inputNow       int64
+		expectedOutput bool
+		name           string
+	}{
+		{
+			inputPause:     nil,
+			inputNow:       100,
+			expectedOutput: false,
+			name:           "nil pause",
+		},
+		{
+			inputPause:     &AutoscalerPause{Timestamp: 50},
+			inputNow:       100,
+			expectedOutput: true,
+			name:           "pause without expiry",
+		},
+		{
+			inputPause:     &AutoscalerPause{Timestamp: 50, Expiry: 150},
+			inputNow:       100,
+			expectedOutput: true,
+			name:           "pause before expiry",
+		},
+		{
+			inputPause:     &AutoscalerPause{Timestamp: 50, Expiry: 100},
+			inputNow:       100,
+			expectedOutput: false,
+			name:           "pause expired",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.inputPause.IsActive(tc.inputNow)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
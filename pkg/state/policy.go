@@ -1,6 +1,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -39,8 +41,63 @@ type ClientScalingPolicy struct {
 	Provider       ClientProvider          `json:"Provider"`
 	ProviderConfig map[string]string       `json:"ProviderConfig"`
 	Checks         map[string]*PolicyCheck `json:"Checks"`
+
+	// ScaleOutCooldown is the time period in seconds, following the completion of a scale out
+	// activity, during which the autoscaler will not trigger further scaling of the class. This
+	// allows new nodes time to join the cluster and take on work before being re-evaluated.
+	ScaleOutCooldown int `json:"ScaleOutCooldown"`
+
+	// ScaleInCooldown is the time period in seconds, following the completion of a scale in
+	// activity, during which the autoscaler will not trigger further scaling of the class.
+	ScaleInCooldown int `json:"ScaleInCooldown"`
+
+	// ScaleOutMode controls how the number of nodes added during a scale out activity is
+	// calculated. Defaults to fixed, which uses the ScaleOutCount.
+	ScaleOutMode ScaleOutMode `json:"ScaleOutMode"`
+
+	// Schedules is a map of cron based windows during which the capacity parameters of the policy
+	// are overridden. The key is a free-form user supplied string identifying the schedule.
+	Schedules map[string]*PolicySchedule `json:"Schedules"`
+
+	// Strategy is the decision strategy used by the autoscaler when evaluating the policy.
+	// Defaults to threshold, which uses the Checks.
+	Strategy PolicyStrategy `json:"Strategy"`
+
+	// Targets is a map of resource utilisation targets used when the policy Strategy is
+	// target-tracking. The key is a free-form user supplied string identifying the target.
+	Targets map[string]*PolicyTarget `json:"Targets"`
+
+	// Expressions is a map of boolean expressions which combine the outcome of the named checks
+	// to decide whether to scale in the direction of the action key. When an action has an
+	// expression, the checks of that action are no longer independently able to trigger scaling.
+	Expressions map[ComparisonAction]string `json:"Expressions"`
+
+	// EvaluationInterval is the time period in seconds between autoscaler evaluations of the
+	// policy. Defaults to 0, which uses the server configured evaluation interval.
+	EvaluationInterval int `json:"EvaluationInterval"`
+
+	// ScaleInSelector configures how the node removed during a scale in activity is selected.
+	// Defaults to the least-allocated strategy weighting cpu and memory equally.
+	ScaleInSelector *ScaleInSelector `json:"ScaleInSelector"`
+
+	// ScaleInProtection identifies jobs whose running allocations protect the node they run on
+	// from being selected for removal during scale in activities.
+	ScaleInProtection *ScaleInProtection `json:"ScaleInProtection"`
+
+	// Drain controls how Nomad drains the node removed during a scale in activity. Defaults to a
+	// 5 minute deadline without a timeout.
+	Drain *DrainConfig `json:"Drain"`
+
+	// ScaleOutJoinTimeout is the time period in seconds, following the provider scale out, within
+	// which the new nodes must join the class as ready and eligible. If the nodes do not join in
+	// time, the scale out activity fails. Defaults to 600.
+	ScaleOutJoinTimeout int `json:"ScaleOutJoinTimeout"`
 }
 
+// defaultScaleOutJoinTimeout is the default time period in seconds allowed for new nodes to join
+// the class following a scale out.
+const defaultScaleOutJoinTimeout = 600
+
 // MarshalZerologObject satisfies the LogObjectMarshaler interface of Zerolog allowing us to log
 // the ClientScalingPolicy as an object.
 func (c ClientScalingPolicy) MarshalZerologObject(e *zerolog.Event) {
@@ -53,6 +110,13 @@ func (c ClientScalingPolicy) MarshalZerologObject(e *zerolog.Event) {
 		Int("max-count", c.MaxCount).
 		Int("scale-in-count", c.ScaleInCount).
 		Int("scale-out-count", c.ScaleOutCount).
+		Int("scale-out-cooldown", c.ScaleOutCooldown).
+		Int("scale-in-cooldown", c.ScaleInCooldown).
+		Int("evaluation-interval", c.EvaluationInterval).
+		Int("scale-out-join-timeout", c.ScaleOutJoinTimeout).
+		Str("scale-out-mode", c.GetScaleOutMode().String()).
+		Str("strategy", c.GetStrategy().String()).
+		Str("scale-in-selector", c.ScaleInSelector.GetStrategy().String()).
 		Str("provider", c.Provider.String())
 
 	// Iterate the provider configuration and add these to the log context.
@@ -76,10 +140,58 @@ func (c ClientScalingPolicy) Validate() error {
 		return err
 	}
 
-	// Currently in its MVP stage, Chemtrail can only handle scaling in with a count of 1. This
-	// will change in the future so the parameter is there but its currently protected.
-	if c.ScaleInCount > 1 {
-		return errors.New("currently Chemtrail can only handle ScaleInCount of 1")
+	// Cooldown periods are durations and therefore cannot be negative.
+	if c.ScaleOutCooldown < 0 || c.ScaleInCooldown < 0 {
+		return errors.New("ScaleOutCooldown and ScaleInCooldown must not be negative")
+	}
+
+	if c.EvaluationInterval < 0 {
+		return errors.New("EvaluationInterval must not be negative")
+	}
+
+	if c.ScaleOutJoinTimeout < 0 {
+		return errors.New("ScaleOutJoinTimeout must not be negative")
+	}
+
+	if err := c.GetScaleOutMode().Validate(); err != nil {
+		return err
+	}
+
+	if err := c.GetStrategy().Validate(); err != nil {
+		return err
+	}
+
+	if c.GetStrategy() == StrategyTargetTracking && len(c.Targets) == 0 {
+		return errors.New("target-tracking Strategy requires at least one target")
+	}
+
+	// Expressions combine the outcome of checks, which are not evaluated by target tracking.
+	if c.GetStrategy() == StrategyTargetTracking && len(c.Expressions) > 0 {
+		return errors.New("Expressions are not supported by the target-tracking Strategy")
+	}
+
+	if err := c.ScaleInSelector.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.ScaleInProtection.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Drain.Validate(); err != nil {
+		return err
+	}
+
+	for name, target := range c.Targets {
+		if err := target.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate target: "+name)
+		}
+	}
+
+	for name, schedule := range c.Schedules {
+		if err := schedule.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate schedule: "+name)
+		}
 	}
 
 	// Depending on the provider, we will have different base requirements for the config.
@@ -93,10 +205,26 @@ func (c ClientScalingPolicy) Validate() error {
 	// Iterate over the checks and validate the required components. The first error is returned,
 	// rather than collecting.
 	for name, check := range c.Checks {
-		if err := check.Resource.Validate(); err != nil {
+		if err := check.GetSource().Validate(); err != nil {
 			return errors.Wrap(err, "failed to validate check: "+name)
 		}
 
+		// The resource is only used by the Nomad source, whereas external sources are queried.
+		switch check.GetSource() {
+		case MetricSourceNomad:
+			if err := check.Resource.Validate(); err != nil {
+				return errors.Wrap(err, "failed to validate check: "+name)
+			}
+		case MetricSourcePrometheus:
+			if check.Query == "" {
+				return errors.New("failed to validate check: " + name + ": prometheus Source requires a Query")
+			}
+			if check.AggregationWindow > 0 {
+				return errors.New("failed to validate check: " + name +
+					": AggregationWindow is not supported by the prometheus Source, aggregate within the Query")
+			}
+		}
+
 		if err := check.ComparisonOperator.Validate(); err != nil {
 			return errors.Wrap(err, "failed to validate check: "+name)
 		}
@@ -104,10 +232,98 @@ func (c ClientScalingPolicy) Validate() error {
 		if err := check.Action.Validate(); err != nil {
 			return errors.Wrap(err, "failed to validate check: "+name)
 		}
+
+		if check.EvaluationPeriods < 0 || check.BreachesRequired < 0 {
+			return errors.New("failed to validate check: " + name +
+				": EvaluationPeriods and BreachesRequired must not be negative")
+		}
+
+		if check.GetBreachesRequired() > check.GetEvaluationPeriods() {
+			return errors.New("failed to validate check: " + name +
+				": BreachesRequired must not be greater than EvaluationPeriods")
+		}
+
+		if check.AggregationWindow < 0 {
+			return errors.New("failed to validate check: " + name + ": AggregationWindow must not be negative")
+		}
+
+		if err := check.GetAggregationFunction().Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate check: "+name)
+		}
+
+		for _, step := range check.StepAdjustments {
+			if step == nil {
+				return errors.New("failed to validate check: " + name + ": StepAdjustments must not contain a null entry")
+			}
+			if err := step.Validate(); err != nil {
+				return errors.Wrap(err, "failed to validate check: "+name)
+			}
+		}
+	}
+
+	for action, expression := range c.Expressions {
+		if err := c.validateExpression(action, expression); err != nil {
+			return errors.Wrap(err, "failed to validate expression: "+action.String())
+		}
+	}
+	return nil
+}
+
+// validateExpression checks the expression can be parsed, and that each check referenced exists,
+// is enabled and performs the same action as the expression. A disabled check never fires, which
+// would leave the expression permanently true or false.
+func (c ClientScalingPolicy) validateExpression(action ComparisonAction, expression string) error {
+	if err := action.Validate(); err != nil {
+		return err
+	}
+
+	expr, err := ParseCheckExpression(expression)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range expr.Checks() {
+		check, ok := c.Checks[name]
+		if !ok {
+			return errors.Errorf("check %q not found", name)
+		}
+		if !check.Enabled {
+			return errors.Errorf("check %q must be enabled", name)
+		}
+		if check.Action != action {
+			return errors.Errorf("check %q Action must be %s", name, action.String())
+		}
 	}
 	return nil
 }
 
+// GetStrategy returns the decision strategy of the policy, applying the default if the value has
+// not been set.
+func (c ClientScalingPolicy) GetStrategy() PolicyStrategy {
+	if c.Strategy == "" {
+		return StrategyThreshold
+	}
+	return c.Strategy
+}
+
+// GetScaleOutJoinTimeout returns the time allowed for new nodes to join the class following a
+// scale out, applying the default if the value has not been set.
+func (c ClientScalingPolicy) GetScaleOutJoinTimeout() time.Duration {
+	if c.ScaleOutJoinTimeout == 0 {
+		return defaultScaleOutJoinTimeout * time.Second
+	}
+	return time.Duration(c.ScaleOutJoinTimeout) * time.Second
+}
+
+// GetScaleOutMode returns the scale out mode of the policy, applying the default if the value has
+// not been set.
+func (c ClientScalingPolicy) GetScaleOutMode() ScaleOutMode {
+	if c.ScaleOutMode == "" {
+		return ScaleOutModeFixed
+	}
+	return c.ScaleOutMode
+}
+
 // PolicyCheck is an individual check to be performed as part of an autoscaling evaluation of the
 // Nomad client class.
 type PolicyCheck struct {
@@ -130,6 +346,74 @@ type PolicyCheck struct {
 	// Action is the scaling action that should be taken if the queried metric fails the comparison
 	// check.
 	Action ComparisonAction `json:"Action"`
+
+	// EvaluationPeriods is the number of most recent evaluations which are considered when
+	// determining whether the check has fired. Defaults to 1, which means the check fires based
+	// solely on the current evaluation.
+	EvaluationPeriods int `json:"EvaluationPeriods"`
+
+	// BreachesRequired is the number of evaluations within the EvaluationPeriods window which must
+	// breach the threshold for the check to fire. Defaults to the value of EvaluationPeriods,
+	// meaning every evaluation within the window must breach.
+	BreachesRequired int `json:"BreachesRequired"`
+
+	// AggregationWindow is the time period in seconds over which resource samples are aggregated
+	// to produce the value compared against the threshold. Defaults to 0, which uses the current
+	// instantaneous resource value.
+	AggregationWindow int `json:"AggregationWindow"`
+
+	// AggregationFunction is the function used to aggregate the resource samples within the
+	// AggregationWindow. Defaults to avg.
+	AggregationFunction AggregationFunction `json:"AggregationFunction"`
+
+	// StepAdjustments allow the number of nodes scaled by to be proportional to the resource
+	// value when the check fires. The first step which contains the value is used, and if none
+	// match, the policy count is used.
+	StepAdjustments []*StepAdjustment `json:"StepAdjustments"`
+
+	// Source identifies where the value compared against the threshold is read from. Defaults to
+	// nomad, which uses the Resource of the check.
+	Source MetricSource `json:"Source"`
+
+	// Query is the PromQL query run when the check Source is prometheus. The query must return a
+	// scalar or a vector containing a single sample.
+	Query string `json:"Query"`
+}
+
+// GetSource returns the metric source of the check, applying the default if the value has not
+// been set.
+func (pc PolicyCheck) GetSource() MetricSource {
+	if pc.Source == "" {
+		return MetricSourceNomad
+	}
+	return pc.Source
+}
+
+// GetEvaluationPeriods returns the number of evaluations the check considers, applying the
+// default if the value has not been set.
+func (pc PolicyCheck) GetEvaluationPeriods() int {
+	if pc.EvaluationPeriods < 1 {
+		return 1
+	}
+	return pc.EvaluationPeriods
+}
+
+// GetAggregationFunction returns the function used to aggregate resource samples, applying the
+// default if the value has not been set.
+func (pc PolicyCheck) GetAggregationFunction() AggregationFunction {
+	if pc.AggregationFunction == "" {
+		return AggregationAverage
+	}
+	return pc.AggregationFunction
+}
+
+// GetBreachesRequired returns the number of breaching evaluations required for the check to fire,
+// applying the default if the value has not been set.
+func (pc PolicyCheck) GetBreachesRequired() int {
+	if pc.BreachesRequired < 1 {
+		return pc.GetEvaluationPeriods()
+	}
+	return pc.BreachesRequired
 }
 
 // MarshalZerologObject satisfies the LogObjectMarshaler interface of Zerolog allowing us to log
@@ -140,7 +424,13 @@ func (pc PolicyCheck) MarshalZerologObject(e *zerolog.Event) {
 		Str("resource", pc.Resource.String()).
 		Str("comparison-operator", pc.ComparisonOperator.String()).
 		Float64("comparison-percentage", pc.ComparisonPercentage).
-		Str("comparison-action", pc.Action.String())
+		Str("comparison-action", pc.Action.String()).
+		Int("evaluation-periods", pc.GetEvaluationPeriods()).
+		Int("breaches-required", pc.GetBreachesRequired()).
+		Int("aggregation-window", pc.AggregationWindow).
+		Str("aggregation-function", pc.GetAggregationFunction().String()).
+		Int("step-adjustments", len(pc.StepAdjustments)).
+		Str("source", pc.GetSource().String())
 }
 
 // ClientProvider is an identifier to the backend which provides the Nomad client workers. This is
@@ -226,7 +516,8 @@ func (r ScaleResource) String() string { return string(r) }
 // Validate checks the ScaleResource is a valid and that it can be handled within the autoscaler.
 func (r ScaleResource) Validate() error {
 	switch r {
-	case ScaleResourceCPU, ScaleResourceMemory:
+	case ScaleResourceCPU, ScaleResourceMemory, ScaleResourceDisk, ScaleResourceNetwork,
+		ScaleResourceDevices, ScaleResourceQueuedAllocs, ScaleResourceCPUUsed, ScaleResourceMemoryUsed:
 		return nil
 	default:
 		return errors.Errorf("ScaleResource \"%s\" is not a valid option", r.String())
@@ -241,4 +532,120 @@ const (
 	// ScaleResourceMemory represents the memory resource stanza parameter in a Nomad job as
 	// specified: https://www.nomadproject.io/docs/job-specification/resources.html#memory
 	ScaleResourceMemory ScaleResource = "memory"
+
+	// ScaleResourceDisk represents the ephemeral disk parameter in a Nomad job as specified:
+	// https://www.nomadproject.io/docs/job-specification/ephemeral_disk.html
+	ScaleResourceDisk ScaleResource = "disk"
+
+	// ScaleResourceNetwork represents the network bandwidth parameter in a Nomad job as specified:
+	// https://www.nomadproject.io/docs/job-specification/network.html
+	ScaleResourceNetwork ScaleResource = "network"
+
+	// ScaleResourceDevices represents the device instances requested in a Nomad job as specified:
+	// https://www.nomadproject.io/docs/job-specification/device.html
+	ScaleResourceDevices ScaleResource = "devices"
+
+	// ScaleResourceQueuedAllocs represents the number of allocations which Nomad has been unable
+	// to place on the class due to resource exhaustion, as tracked via blocked evaluations. Checks
+	// using this resource compare against an absolute count rather than a percentage.
+	ScaleResourceQueuedAllocs ScaleResource = "queued-allocs"
+
+	// ScaleResourceCPUUsed represents the CPU actually used by the running allocations as a
+	// percentage of the allocatable CPU. This requires the resource usage collector to be enabled.
+	ScaleResourceCPUUsed ScaleResource = "cpu-used"
+
+	// ScaleResourceMemoryUsed represents the memory actually used by the running allocations as a
+	// percentage of the allocatable memory. This requires the resource usage collector to be
+	// enabled.
+	ScaleResourceMemoryUsed ScaleResource = "memory-used"
+)
+
+// IsUsage identifies whether the ScaleResource is based on the actual resource usage of the
+// running allocations, rather than the allocated resources.
+func (r ScaleResource) IsUsage() bool {
+	return r == ScaleResourceCPUUsed || r == ScaleResourceMemoryUsed
+}
+
+// AggregationFunction is the function used to aggregate resource samples collected within a check
+// aggregation window into a single value.
+type AggregationFunction string
+
+// String returns the string form of the AggregationFunction.
+func (af AggregationFunction) String() string { return string(af) }
+
+// Validate checks the AggregationFunction is valid and that it can be handled within the
+// autoscaler.
+func (af AggregationFunction) Validate() error {
+	switch af {
+	case AggregationAverage, AggregationMax, AggregationMin, AggregationP95:
+		return nil
+	default:
+		return errors.Errorf("AggregationFunction \"%s\" is not a valid option", af.String())
+	}
+}
+
+const (
+	// AggregationAverage uses the mean value of the samples.
+	AggregationAverage AggregationFunction = "avg"
+
+	// AggregationMax uses the largest value of the samples.
+	AggregationMax AggregationFunction = "max"
+
+	// AggregationMin uses the smallest value of the samples.
+	AggregationMin AggregationFunction = "min"
+
+	// AggregationP95 uses the 95th percentile value of the samples.
+	AggregationP95 AggregationFunction = "p95"
+)
+
+// ScaleOutMode identifies how the number of nodes to add during a scale out activity is
+// calculated.
+type ScaleOutMode string
+
+// String returns the string form of the ScaleOutMode.
+func (m ScaleOutMode) String() string { return string(m) }
+
+// Validate checks the ScaleOutMode is valid and that it can be handled within Chemtrail.
+func (m ScaleOutMode) Validate() error {
+	switch m {
+	case ScaleOutModeFixed, ScaleOutModeBinPack:
+		return nil
+	default:
+		return errors.Errorf("ScaleOutMode \"%s\" is not a valid option", m.String())
+	}
+}
+
+const (
+	// ScaleOutModeFixed adds the number of nodes configured by the policy ScaleOutCount.
+	ScaleOutModeFixed ScaleOutMode = "fixed"
+
+	// ScaleOutModeBinPack simulates placing the queued allocations of the class onto nodes shaped
+	// like the existing nodes, and adds the number of nodes required capped by the MaxCount. If
+	// there are no queued allocations, the ScaleOutCount is used.
+	ScaleOutModeBinPack ScaleOutMode = "bin-pack"
+)
+
+// MetricSource identifies where the value of a policy check is read from.
+type MetricSource string
+
+// String returns the string form of the MetricSource.
+func (ms MetricSource) String() string { return string(ms) }
+
+// Validate checks the MetricSource is valid and that it can be handled within the autoscaler.
+func (ms MetricSource) Validate() error {
+	switch ms {
+	case MetricSourceNomad, MetricSourcePrometheus:
+		return nil
+	default:
+		return errors.Errorf("Source \"%s\" is not a valid option", ms.String())
+	}
+}
+
+const (
+	// MetricSourceNomad uses the Nomad class resource stats tracked by Chemtrail.
+	MetricSourceNomad MetricSource = "nomad"
+
+	// MetricSourcePrometheus runs the check Query as a PromQL instant query against the
+	// configured Prometheus server.
+	MetricSourcePrometheus MetricSource = "prometheus"
 )
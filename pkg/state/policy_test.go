@@ -126,3 +126,195 @@ func TestComparisonAction_Validate(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyCheck_GetBreachesRequired(t *testing.T) {
+	testCases := []struct {
+		inputPolicyCheck PolicyCheck
+		expectedPeriods  int
+		expectedBreaches int
+		name             string
+	}{
+		{
+			inputPolicyCheck: PolicyCheck{},
+			expectedPeriods:  1,
+			expectedBreaches: 1,
+			name:             "unset evaluation parameters",
+		},
+		{
+			inputPolicyCheck: PolicyCheck{EvaluationPeriods: 5},
+			expectedPeriods:  5,
+			expectedBreaches: 5,
+			name:             "unset breaches required",
+		},
+		{
+			inputPolicyCheck: PolicyCheck{EvaluationPeriods: 5, BreachesRequired: 3},
+			expectedPeriods:  5,
+			expectedBreaches: 3,
+			name:             "both evaluation parameters set",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPeriods, tc.inputPolicyCheck.GetEvaluationPeriods(), tc.name)
+		assert.Equal(t, tc.expectedBreaches, tc.inputPolicyCheck.GetBreachesRequired(), tc.name)
+	}
+}
+
+func TestAggregationFunction_Validate(t *testing.T) {
+	testCases := []struct {
+		inputAggregationFunction AggregationFunction
+		expectedOutput           error
+		name                     string
+	}{
+		{
+			inputAggregationFunction: AggregationAverage,
+			expectedOutput:           nil,
+			name:                     "avg",
+		},
+		{
+			inputAggregationFunction: AggregationP95,
+			expectedOutput:           nil,
+			name:                     "p95",
+		},
+		{
+			inputAggregationFunction: "median",
+			expectedOutput:           errors.New("AggregationFunction \"median\" is not a valid option"),
+			name:                     "invalid aggregation function",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.inputAggregationFunction.Validate()
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput, tc.name)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error(), tc.name)
+		}
+	}
+}
+
+func TestScaleResource_Validate(t *testing.T) {
+	testCases := []struct {
+		inputScaleResource ScaleResource
+		expectedOutput     error
+		name               string
+	}{
+		{
+			inputScaleResource: ScaleResourceCPU,
+			expectedOutput:     nil,
+			name:               "cpu",
+		},
+		{
+			inputScaleResource: ScaleResourceDisk,
+			expectedOutput:     nil,
+			name:               "disk",
+		},
+		{
+			inputScaleResource: ScaleResourceNetwork,
+			expectedOutput:     nil,
+			name:               "network",
+		},
+		{
+			inputScaleResource: ScaleResourceDevices,
+			expectedOutput:     nil,
+			name:               "devices",
+		},
+		{
+			inputScaleResource: "iops",
+			expectedOutput:     errors.New("ScaleResource \"iops\" is not a valid option"),
+			name:               "invalid resource",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.inputScaleResource.Validate()
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput, tc.name)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error(), tc.name)
+		}
+	}
+}
+
+func TestClientScalingPolicy_ValidateStepAdjustments(t *testing.T) {
+	newPolicy := func(steps []*StepAdjustment) ClientScalingPolicy {
+		return ClientScalingPolicy{
+			Provider: NoOpClientProvider,
+			Checks: map[string]*PolicyCheck{
+				"cpu-out": {
+					Enabled:              true,
+					Resource:             ScaleResourceCPU,
+					ComparisonOperator:   ComparisonGreaterThan,
+					ComparisonPercentage: 80,
+					Action:               ActionScaleOut,
+					StepAdjustments:      steps,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		inputSteps  []*StepAdjustment
+		expectError bool
+		name        string
+	}{
+		{
+			inputSteps:  []*StepAdjustment{{LowerBound: 90, Adjustment: 2}},
+			expectError: false,
+			name:        "valid step adjustment",
+		},
+		{
+			inputSteps:  []*StepAdjustment{{LowerBound: 90, Adjustment: 2}, nil},
+			expectError: true,
+			name:        "null step adjustment",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := newPolicy(tc.inputSteps).Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestClientScalingPolicy_ValidateTargetTrackingExpressions(t *testing.T) {
+	newPolicy := func(expressions map[ComparisonAction]string) ClientScalingPolicy {
+		return ClientScalingPolicy{
+			Provider: NoOpClientProvider,
+			Strategy: StrategyTargetTracking,
+			Targets: map[string]*PolicyTarget{
+				"cpu": {Enabled: true, Resource: ScaleResourceCPU, TargetValue: 70},
+			},
+			Expressions: expressions,
+		}
+	}
+
+	testCases := []struct {
+		inputExpressions map[ComparisonAction]string
+		expectError      bool
+		name             string
+	}{
+		{
+			inputExpressions: nil,
+			expectError:      false,
+			name:             "no expressions",
+		},
+		{
+			inputExpressions: map[ComparisonAction]string{ActionScaleIn: "cpu-in"},
+			expectError:      true,
+			name:             "expressions with target tracking",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := newPolicy(tc.inputExpressions).Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package state
+
+import (
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+// ScaleInProtection identifies jobs whose running allocations protect the node they run on from
+// being selected for removal during scale in activities. A job matches if its ID, type or any of
+// the meta selectors match.
+type ScaleInProtection struct {
+
+	// JobIDs is a list of job IDs which protect the nodes they run on.
+	JobIDs []string `json:"JobIDs"`
+
+	// JobTypes is a list of job types, such as system, which protect the nodes they run on.
+	JobTypes []string `json:"JobTypes"`
+
+	// JobMeta is a map of job meta keys to values. Jobs which contain any of the key/value pairs
+	// within their meta protect the nodes they run on.
+	JobMeta map[string]string `json:"JobMeta"`
+
+	// Window restricts the protection to a cron based time window, such as business hours. When
+	// not set, the protection always applies.
+	Window *ProtectionWindow `json:"Window"`
+}
+
+// ProtectionWindow is a cron based time window during which a ScaleInProtection applies.
+type ProtectionWindow struct {
+
+	// Cron is the cron expression which defines when each window starts.
+	Cron string `json:"Cron"`
+
+	// TimeZone is the IANA time zone name used when evaluating the cron expression. Defaults to
+	// UTC when not set.
+	TimeZone string `json:"TimeZone"`
+
+	// Duration is the time period in seconds for which each window lasts once started.
+	Duration int `json:"Duration"`
+}
+
+// Validate checks the ScaleInProtection contains at least one job selector and that each
+// selector is sensible.
+func (p *ScaleInProtection) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.JobIDs) == 0 && len(p.JobTypes) == 0 && len(p.JobMeta) == 0 {
+		return errors.New("ScaleInProtection must contain at least one of JobIDs, JobTypes or JobMeta")
+	}
+
+	for _, id := range p.JobIDs {
+		if id == "" {
+			return errors.New("ScaleInProtection JobIDs must not contain an empty ID")
+		}
+	}
+
+	for _, jobType := range p.JobTypes {
+		switch jobType {
+		case "service", "batch", "system":
+		default:
+			return errors.Errorf("ScaleInProtection JobType \"%s\" is not a valid option", jobType)
+		}
+	}
+
+	for key := range p.JobMeta {
+		if key == "" {
+			return errors.New("ScaleInProtection JobMeta must not contain an empty key")
+		}
+	}
+
+	if p.Window != nil {
+		if err := p.Window.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate ScaleInProtection Window")
+		}
+	}
+	return nil
+}
+
+// IsActive returns whether the protection applies at the passed time. A nil protection is never
+// active, and a protection without a window is always active.
+func (p *ScaleInProtection) IsActive(now time.Time) (bool, error) {
+	switch {
+	case p == nil:
+		return false, nil
+	case p.Window == nil:
+		return true, nil
+	default:
+		return p.Window.IsActive(now)
+	}
+}
+
+// MatchesJob returns whether the job with the passed ID, type and meta is protected.
+func (p *ScaleInProtection) MatchesJob(id, jobType string, meta map[string]string) bool {
+	if p == nil {
+		return false
+	}
+
+	for _, protected := range p.JobIDs {
+		if protected == id {
+			return true
+		}
+	}
+
+	for _, protected := range p.JobTypes {
+		if protected == jobType {
+			return true
+		}
+	}
+
+	for k, v := range p.JobMeta {
+		if value, ok := meta[k]; ok && value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the window contains a parsable cron expression and time zone, along with a
+// positive duration.
+func (w *ProtectionWindow) Validate() error {
+	if _, err := cronexpr.Parse(w.Cron); err != nil {
+		return errors.Wrap(err, "failed to parse Cron")
+	}
+
+	if _, err := w.schedule().location(); err != nil {
+		return errors.Wrap(err, "failed to load TimeZone")
+	}
+
+	if w.Duration < 1 {
+		return errors.New("Duration must be greater than 0")
+	}
+	return nil
+}
+
+// IsActive returns whether a window is active at the passed time.
+func (w *ProtectionWindow) IsActive(now time.Time) (bool, error) {
+	active, _, err := w.schedule().windows("", now)
+	if err != nil {
+		return false, err
+	}
+	return active != nil, nil
+}
+
+// schedule returns the window as a PolicySchedule, allowing the schedule window calculations to be
+// shared. The capacity overrides of the schedule are not used.
+func (w *ProtectionWindow) schedule() *PolicySchedule {
+	return &PolicySchedule{Cron: w.Cron, TimeZone: w.TimeZone, Duration: w.Duration}
+}
@@ -0,0 +1,173 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleInProtection_Validate(t *testing.T) {
+	testCases := []struct {
+		inputProtection *ScaleInProtection
+		expectError     bool
+		name            string
+	}{
+		{
+			inputProtection: nil,
+			expectError:     false,
+			name:            "nil protection",
+		},
+		{
+			inputProtection: &ScaleInProtection{
+				JobIDs:   []string{"postgres"},
+				JobTypes: []string{"system"},
+				JobMeta:  map[string]string{"stateful": "true"},
+				Window:   &ProtectionWindow{Cron: "0 9 * * 1-5", TimeZone: "Europe/London", Duration: 28800},
+			},
+			expectError: false,
+			name:        "valid protection",
+		},
+		{
+			inputProtection: &ScaleInProtection{},
+			expectError:     true,
+			name:            "no job selectors",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobIDs: []string{""}},
+			expectError:     true,
+			name:            "empty job ID",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobTypes: []string{"daemon"}},
+			expectError:     true,
+			name:            "unsupported job type",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobMeta: map[string]string{"": "true"}},
+			expectError:     true,
+			name:            "empty job meta key",
+		},
+		{
+			inputProtection: &ScaleInProtection{
+				JobIDs: []string{"postgres"},
+				Window: &ProtectionWindow{Cron: "0 9 * * 1-5"},
+			},
+			expectError: true,
+			name:        "window without duration",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputProtection.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestScaleInProtection_MatchesJob(t *testing.T) {
+	protection := &ScaleInProtection{
+		JobIDs:   []string{"postgres"},
+		JobTypes: []string{"system"},
+		JobMeta:  map[string]string{"stateful": "true"},
+	}
+
+	testCases := []struct {
+		inputID        string
+		inputType      string
+		inputMeta      map[string]string
+		expectedOutput bool
+		name           string
+	}{
+		{
+			inputID:        "postgres",
+			inputType:      "service",
+			expectedOutput: true,
+			name:           "matching job ID",
+		},
+		{
+			inputID:        "node-exporter",
+			inputType:      "system",
+			expectedOutput: true,
+			name:           "matching job type",
+		},
+		{
+			inputID:        "redis",
+			inputType:      "service",
+			inputMeta:      map[string]string{"stateful": "true"},
+			expectedOutput: true,
+			name:           "matching job meta",
+		},
+		{
+			inputID:        "redis",
+			inputType:      "service",
+			inputMeta:      map[string]string{"stateful": "false"},
+			expectedOutput: false,
+			name:           "job meta value mismatch",
+		},
+		{
+			inputID:        "web",
+			inputType:      "batch",
+			expectedOutput: false,
+			name:           "no match",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, protection.MatchesJob(tc.inputID, tc.inputType, tc.inputMeta), tc.name)
+	}
+
+	var nilProtection *ScaleInProtection
+	assert.False(t, nilProtection.MatchesJob("postgres", "service", nil))
+}
+
+func TestScaleInProtection_IsActive(t *testing.T) {
+	businessHours := &ProtectionWindow{Cron: "0 9 * * 1-5", Duration: 28800}
+
+	testCases := []struct {
+		inputProtection *ScaleInProtection
+		inputTime       time.Time
+		expectedOutput  bool
+		name            string
+	}{
+		{
+			inputProtection: nil,
+			inputTime:       time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC),
+			expectedOutput:  false,
+			name:            "nil protection",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobIDs: []string{"postgres"}},
+			inputTime:       time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC),
+			expectedOutput:  true,
+			name:            "protection without window",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobIDs: []string{"postgres"}, Window: businessHours},
+			inputTime:       time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC),
+			expectedOutput:  true,
+			name:            "within window",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobIDs: []string{"postgres"}, Window: businessHours},
+			inputTime:       time.Date(2020, 1, 6, 18, 0, 0, 0, time.UTC),
+			expectedOutput:  false,
+			name:            "after window",
+		},
+		{
+			inputProtection: &ScaleInProtection{JobIDs: []string{"postgres"}, Window: businessHours},
+			inputTime:       time.Date(2020, 1, 5, 10, 0, 0, 0, time.UTC),
+			expectedOutput:  false,
+			name:            "weekend",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput, err := tc.inputProtection.IsActive(tc.inputTime)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/gofrs/uuid"
 	"github.com/rs/zerolog"
 )
@@ -28,6 +30,19 @@ type ScaleBackend interface {
 	// WriteRequestEvent updates the stored request details with the passed event. This should not
 	// overwrite the stored data, but append to the list of stored events.
 	WriteRequestEvent(message *ScalingUpdate) error
+
+	// GetEvaluations returns all the currently stored autoscaler evaluation records. The map is
+	// keyed by the class, and the records of each class are ordered newest first.
+	GetEvaluations() (map[string][]*EvaluationRecord, error)
+
+	// GetClassEvaluations returns the stored autoscaler evaluation records of the class, ordered
+	// newest first. If the class has no records, an empty list is returned.
+	GetClassEvaluations(class string) ([]*EvaluationRecord, error)
+
+	// WriteEvaluation is used to store the record of an autoscaler evaluation. Only the most recent
+	// EvaluationRecordRetention records of each class should be retained, and records should be
+	// removed during garbage collection once older than the configured threshold.
+	WriteEvaluation(record *EvaluationRecord) error
 }
 
 const (
@@ -47,6 +62,9 @@ type ScalingActivity struct {
 	// ScaleDirection is the direction of scaling.
 	Direction ScaleDirection
 
+	// Class is the Nomad client class which the scaling operation targeted.
+	Class string
+
 	// LastUpdate is the UnixNano timestamp of the last update to the scaling operation and if the
 	// status is terminal, indicates the time of the last action of the scaling event.
 	LastUpdate int64
@@ -61,12 +79,54 @@ type ScalingActivity struct {
 	// provider. This should not include credentials, but instead items such as ASG name,
 	// instanceIDs or IP addresses.
 	ProviderCfg map[string]string
+
+	// Nodes records the outcome of each node added or removed by the scaling activity, keyed by
+	// the Nomad node ID.
+	Nodes map[string]*NodeActivity
+}
+
+// NodeActivity is the outcome of an individual node added or removed by a scaling activity.
+type NodeActivity struct {
+
+	// Status is the status of the node. Nodes which are being drained are in-progress, while
+	// nodes which have been removed by the provider, or have joined the class, are completed.
+	Status ScaleStatus
+
+	// Message describes the latest update of the node.
+	Message string
+
+	// LastUpdate is the UnixNano timestamp of the latest update of the node.
+	LastUpdate int64
+}
+
+// RecordNodeUpdate updates the outcome of the node referenced by the scaling update. Updates which
+// do not reference a node are ignored.
+func (sa *ScalingActivity) RecordNodeUpdate(update *ScalingUpdate) {
+	if update.NodeID == "" {
+		return
+	}
+
+	if sa.Nodes == nil {
+		sa.Nodes = make(map[string]*NodeActivity)
+	}
+	sa.Nodes[update.NodeID] = &NodeActivity{
+		Status:     update.NodeStatus,
+		Message:    update.Detail.Message,
+		LastUpdate: update.Detail.Timestamp,
+	}
 }
 
 type ScalingUpdate struct {
 	ID     uuid.UUID
 	Status ScaleStatus
 	Detail Event
+
+	// NodeID is the ID of the node the update refers to, if the update details the removal of an
+	// individual node.
+	NodeID string
+
+	// NodeStatus is the status of the node referenced by NodeID.
+	NodeStatus ScaleStatus
 }
 
 func (su ScalingUpdate) MarshalZerologObject(e *zerolog.Event) {
@@ -84,14 +144,76 @@ type Event struct {
 }
 
 type ScalingRequest struct {
-	ID           uuid.UUID
-	Direction    ScaleDirection
-	TargetNodeID string
-	Policy       *ClientScalingPolicy
+	ID        uuid.UUID
+	Direction ScaleDirection
+	Policy    *ClientScalingPolicy
+
+	// TargetNodeIDs are the IDs of the nodes which will be removed when scaling in. These are
+	// discovered during the precondition checks, so that the checked nodes are those removed.
+	TargetNodeIDs []string
+
+	// Count is the number of nodes the scaling activity should add or remove. If this is not set,
+	// the policy ScaleOutCount or ScaleInCount is used depending on the direction.
+	Count int
+
+	// Simulation holds the result of the bin-packing scale out simulation if one was performed to
+	// calculate the count.
+	Simulation *ScaleOutSimulation
 }
 
 func (sr ScalingRequest) MarshalZerologObject(e *zerolog.Event) {
-	e.Str("id", sr.ID.String()).Str("direction", sr.Direction.String())
+	e.Str("id", sr.ID.String()).Str("direction", sr.Direction.String()).Int("count", sr.GetCount())
+
+	if len(sr.TargetNodeIDs) > 0 {
+		e.Strs("target-nodes", sr.TargetNodeIDs)
+	}
+}
+
+// GetCount returns the number of nodes the scaling activity should add or remove, falling back to
+// the policy configuration if the request count is not set.
+func (sr ScalingRequest) GetCount() int {
+	if sr.Count > 0 || sr.Policy == nil {
+		return sr.Count
+	}
+
+	switch sr.Direction {
+	case ScaleDirectionIn:
+		return sr.Policy.ScaleInCount
+	case ScaleDirectionOut:
+		return sr.Policy.ScaleOutCount
+	default:
+		return 0
+	}
+}
+
+// ScaleOutSimulation is the result of simulating the placement of queued allocations onto the
+// existing nodes of a class, and new nodes shaped like those existing.
+type ScaleOutSimulation struct {
+
+	// QueuedAllocs is the number of queued allocations, with known resource asks, which were
+	// included within the simulation.
+	QueuedAllocs int
+
+	// PlacedExisting is the number of queued allocations which fit onto the free capacity of the
+	// existing nodes within the class.
+	PlacedExisting int
+
+	// PlacedNew is the number of queued allocations which were placed onto new nodes.
+	PlacedNew int
+
+	// Unplaceable is the number of queued allocations which are too large to fit onto an empty
+	// node shaped like those in the class.
+	Unplaceable int
+
+	// NodesRequired is the number of new nodes required to place the queued allocations.
+	NodesRequired int
+}
+
+// String returns a human readable summary of the simulation result.
+func (s ScaleOutSimulation) String() string {
+	return fmt.Sprintf("bin-packing simulation of %v queued allocations placed %v on existing nodes "+
+		"and %v on %v new nodes, %v unplaceable",
+		s.QueuedAllocs, s.PlacedExisting, s.PlacedNew, s.NodesRequired, s.Unplaceable)
 }
 
 // ScaleDirection describes the direction which a scaling activity should take.
@@ -143,6 +265,11 @@ type EventMessage struct {
 	Source    string
 	Message   string
 	Error     error
+
+	// NodeID and NodeStatus are set when the event details an individual node being added or
+	// removed, allowing the outcome of each node to be recorded within the scaling activity.
+	NodeID     string
+	NodeStatus ScaleStatus
 }
 
 func (em EventMessage) MarshalZerologObject(e *zerolog.Event) {
@@ -156,6 +283,9 @@ func (em EventMessage) MarshalZerologObject(e *zerolog.Event) {
 	if em.Message != "" {
 		e.Str("message", em.Message)
 	}
+	if em.NodeID != "" {
+		e.Str("node-id", em.NodeID).Str("node-status", em.NodeStatus.String())
+	}
 }
 
 const (
@@ -16,21 +16,27 @@ import (
 // state is stored.
 const baseEventKVPath = "state/events/"
 
+// baseEvaluationKVPath is Consul path suffix added to the CLI param which identifies where
+// autoscaler evaluation records are stored. Each class has a single key holding its records.
+const baseEvaluationKVPath = "state/evaluations/"
+
 // ScaleBackend is the Consul implementation of the state.ScaleBackend interface.
 type ScaleBackend struct {
-	eventPath   string
-	gcThreshold int64
-	kv          *api.KV
-	logger      zerolog.Logger
+	eventPath      string
+	evaluationPath string
+	gcThreshold    int64
+	kv             *api.KV
+	logger         zerolog.Logger
 }
 
 // NewPolicyBackend returns the Consul implementation of the state.ScaleBackend interface.
 func NewScaleBackend(log zerolog.Logger, path string, client *api.Client) state.ScaleBackend {
 	return &ScaleBackend{
-		eventPath:   path + baseEventKVPath,
-		gcThreshold: state.GarbageCollectionThreshold,
-		kv:          client.KV(),
-		logger:      log,
+		eventPath:      path + baseEventKVPath,
+		evaluationPath: path + baseEvaluationKVPath,
+		gcThreshold:    state.GarbageCollectionThreshold,
+		kv:             client.KV(),
+		logger:         log,
 	}
 }
 
@@ -131,6 +137,42 @@ func (s *ScaleBackend) RunStateGarbageCollection() {
 			continue
 		}
 	}
+
+	s.runEvaluationGarbageCollection(gc)
+}
+
+// runEvaluationGarbageCollection removes the evaluation records older than the threshold,
+// deleting the class key if no records remain.
+func (s *ScaleBackend) runEvaluationGarbageCollection(threshold int64) {
+	evaluations, err := s.GetEvaluations()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("GC failed to list evaluations in Consul backend")
+		return
+	}
+
+	for class, records := range evaluations {
+		pruned := state.PruneEvaluationRecords(records, threshold)
+		if len(pruned) == len(records) {
+			continue
+		}
+
+		if len(pruned) == 0 {
+			if _, err := s.kv.Delete(s.evaluationPath+class, nil); err != nil {
+				s.logger.Error().
+					Str("class", class).
+					Err(err).
+					Msg("GC failed to delete stale evaluations in Consul backend")
+			}
+			continue
+		}
+
+		if err := s.putClassEvaluations(class, pruned); err != nil {
+			s.logger.Error().
+				Str("class", class).
+				Err(err).
+				Msg("GC failed to update stale evaluations in Consul backend")
+		}
+	}
 }
 
 // WriteRequest satisfies the WriteRequest function on the state.ScaleBackend interface.
@@ -144,6 +186,7 @@ func (s *ScaleBackend) WriteRequest(req *state.ScalingRequest) error {
 			Source:    state.ScaleChemtrailSource,
 		}},
 		Direction:   req.Direction,
+		Class:       req.Policy.Class,
 		LastUpdate:  ts,
 		Status:      state.ScaleStatusStarted,
 		Provider:    req.Policy.Provider,
@@ -192,6 +235,7 @@ func (s *ScaleBackend) WriteRequestEvent(message *state.ScalingUpdate) error {
 	}
 	event.Events = append(event.Events, detail)
 	event.LastUpdate = message.Detail.Timestamp
+	event.RecordNodeUpdate(message)
 
 	if message.Status != state.ScaleStatusInProgress {
 		event.Status = message.Status
@@ -210,3 +254,70 @@ func (s *ScaleBackend) WriteRequestEvent(message *state.ScalingUpdate) error {
 	_, err = s.kv.Put(pair, nil)
 	return err
 }
+
+// GetEvaluations satisfies the GetEvaluations function on the state.ScaleBackend interface.
+func (s *ScaleBackend) GetEvaluations() (map[string][]*state.EvaluationRecord, error) {
+	kv, _, err := s.kv.List(s.evaluationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]*state.EvaluationRecord)
+
+	for i := range kv {
+		var records []*state.EvaluationRecord
+
+		if err := json.Unmarshal(kv[i].Value, &records); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+		}
+
+		keySplit := strings.Split(kv[i].Key, "/")
+		out[keySplit[len(keySplit)-1]] = records
+	}
+	return out, nil
+}
+
+// GetClassEvaluations satisfies the GetClassEvaluations function on the state.ScaleBackend
+// interface.
+func (s *ScaleBackend) GetClassEvaluations(class string) ([]*state.EvaluationRecord, error) {
+	kv, _, err := s.kv.Get(s.evaluationPath+class, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []*state.EvaluationRecord{}
+
+	if kv == nil {
+		return out, nil
+	}
+
+	if err := json.Unmarshal(kv.Value, &out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+	return out, nil
+}
+
+// WriteEvaluation satisfies the WriteEvaluation function on the state.ScaleBackend interface.
+func (s *ScaleBackend) WriteEvaluation(record *state.EvaluationRecord) error {
+	records, err := s.GetClassEvaluations(record.Class)
+	if err != nil {
+		return err
+	}
+	return s.putClassEvaluations(record.Class, state.AddEvaluationRecord(records, record))
+}
+
+// putClassEvaluations writes the evaluation records of the class, overwriting any stored records.
+func (s *ScaleBackend) putClassEvaluations(class string, records []*state.EvaluationRecord) error {
+	marshal, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	pair := &api.KVPair{
+		Key:   s.evaluationPath + class,
+		Value: marshal,
+	}
+
+	_, err = s.kv.Put(pair, nil)
+	return err
+}
@@ -11,11 +11,17 @@ import (
 type ScaleBackend struct {
 	events map[uuid.UUID]*state.ScalingActivity
 	l      sync.RWMutex
+
+	// evaluations holds the evaluation records of each class, keyed by the class and ordered
+	// newest first.
+	evaluations     map[string][]*state.EvaluationRecord
+	evaluationsLock sync.RWMutex
 }
 
 func NewScaleStateBackend() state.ScaleBackend {
 	return &ScaleBackend{
-		events: make(map[uuid.UUID]*state.ScalingActivity),
+		events:      make(map[uuid.UUID]*state.ScalingActivity),
+		evaluations: make(map[string][]*state.EvaluationRecord),
 	}
 }
 
@@ -50,6 +56,16 @@ func (s *ScaleBackend) RunStateGarbageCollection() {
 		}
 	}
 	s.l.Unlock()
+
+	s.evaluationsLock.Lock()
+	for class, records := range s.evaluations {
+		if records = state.PruneEvaluationRecords(records, threshold); len(records) == 0 {
+			delete(s.evaluations, class)
+		} else {
+			s.evaluations[class] = records
+		}
+	}
+	s.evaluationsLock.Unlock()
 }
 
 // WriteRequestEvent satisfies the WriteRequestEvent function on the state.ScaleBackend interface.
@@ -66,6 +82,7 @@ func (s *ScaleBackend) WriteRequestEvent(message *state.ScalingUpdate) error {
 
 	event.Events = append(event.Events, detail)
 	event.LastUpdate = message.Detail.Timestamp
+	event.RecordNodeUpdate(message)
 
 	if message.Status != state.ScaleStatusInProgress {
 		event.Status = message.Status
@@ -89,6 +106,7 @@ func (s *ScaleBackend) WriteRequest(req *state.ScalingRequest) error {
 			Source:    state.ScaleChemtrailSource,
 		}},
 		Direction:   req.Direction,
+		Class:       req.Policy.Class,
 		LastUpdate:  ts,
 		Status:      state.ScaleStatusStarted,
 		Provider:    req.Policy.Provider,
@@ -101,3 +119,31 @@ func (s *ScaleBackend) WriteRequest(req *state.ScalingRequest) error {
 
 	return nil
 }
+
+// GetEvaluations satisfies the GetEvaluations function on the state.ScaleBackend interface.
+func (s *ScaleBackend) GetEvaluations() (map[string][]*state.EvaluationRecord, error) {
+	s.evaluationsLock.RLock()
+	defer s.evaluationsLock.RUnlock()
+
+	out := make(map[string][]*state.EvaluationRecord, len(s.evaluations))
+	for class, records := range s.evaluations {
+		out[class] = append([]*state.EvaluationRecord(nil), records...)
+	}
+	return out, nil
+}
+
+// GetClassEvaluations satisfies the GetClassEvaluations function on the state.ScaleBackend
+// interface.
+func (s *ScaleBackend) GetClassEvaluations(class string) ([]*state.EvaluationRecord, error) {
+	s.evaluationsLock.RLock()
+	defer s.evaluationsLock.RUnlock()
+	return append([]*state.EvaluationRecord{}, s.evaluations[class]...), nil
+}
+
+// WriteEvaluation satisfies the WriteEvaluation function on the state.ScaleBackend interface.
+func (s *ScaleBackend) WriteEvaluation(record *state.EvaluationRecord) error {
+	s.evaluationsLock.Lock()
+	s.evaluations[record.Class] = state.AddEvaluationRecord(s.evaluations[record.Class], record)
+	s.evaluationsLock.Unlock()
+	return nil
+}
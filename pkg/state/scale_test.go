@@ -61,3 +61,63 @@ func TestScaleStatus_String(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
 	}
 }
+
+func TestScalingRequest_GetCount(t *testing.T) {
+	policy := &ClientScalingPolicy{ScaleInCount: 1, ScaleOutCount: 2}
+
+	testCases := []struct {
+		inputRequest   ScalingRequest
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputRequest:   ScalingRequest{Direction: ScaleDirectionOut, Policy: policy},
+			expectedOutput: 2,
+			name:           "scale out uses policy count",
+		},
+		{
+			inputRequest:   ScalingRequest{Direction: ScaleDirectionIn, Policy: policy},
+			expectedOutput: 1,
+			name:           "scale in uses policy count",
+		},
+		{
+			inputRequest:   ScalingRequest{Direction: ScaleDirectionOut, Policy: policy, Count: 5},
+			expectedOutput: 5,
+			name:           "request count overrides policy",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.inputRequest.GetCount()
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func TestScalingActivity_RecordNodeUpdate(t *testing.T) {
+	activity := &ScalingActivity{}
+
+	activity.RecordNodeUpdate(&ScalingUpdate{Detail: Event{Timestamp: 1, Message: "activity update"}})
+	assert.Nil(t, activity.Nodes)
+
+	activity.RecordNodeUpdate(&ScalingUpdate{
+		Detail:     Event{Timestamp: 2, Message: "started drain of node node-a"},
+		NodeID:     "node-a",
+		NodeStatus: ScaleStatusInProgress,
+	})
+	activity.RecordNodeUpdate(&ScalingUpdate{
+		Detail:     Event{Timestamp: 3, Message: "failed to drain node node-b"},
+		NodeID:     "node-b",
+		NodeStatus: ScaleStatusFailed,
+	})
+	activity.RecordNodeUpdate(&ScalingUpdate{
+		Detail:     Event{Timestamp: 4, Message: "successfully removed node node-a using provider"},
+		NodeID:     "node-a",
+		NodeStatus: ScaleStatusCompleted,
+	})
+
+	expected := map[string]*NodeActivity{
+		"node-a": {Status: ScaleStatusCompleted, Message: "successfully removed node node-a using provider", LastUpdate: 4},
+		"node-b": {Status: ScaleStatusFailed, Message: "failed to drain node node-b", LastUpdate: 3},
+	}
+	assert.Equal(t, expected, activity.Nodes)
+}
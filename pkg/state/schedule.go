@@ -0,0 +1,201 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+// PolicySchedule is a cron based window during which the capacity parameters of the scaling
+// policy are overridden. This allows operators to scale the class ahead of known demand, such as
+// scaling up before business hours and down overnight.
+type PolicySchedule struct {
+
+	// Cron is the cron expression which defines when each window of the schedule starts.
+	Cron string `json:"Cron"`
+
+	// TimeZone is the IANA time zone name used when evaluating the cron expression. Defaults to
+	// UTC when not set.
+	TimeZone string `json:"TimeZone"`
+
+	// Duration is the time period in seconds for which each window lasts once started.
+	Duration int `json:"Duration"`
+
+	// MinCount overrides the policy MinCount during the window. A value of 0 does not override
+	// the policy.
+	MinCount int `json:"MinCount"`
+
+	// MaxCount overrides the policy MaxCount during the window. A value of 0 does not override
+	// the policy.
+	MaxCount int `json:"MaxCount"`
+
+	// DesiredCount is the number of nodes the autoscaler should maintain within the class during
+	// the window, bounded by the minimum and maximum counts. A value of 0 does not set a desired
+	// count.
+	DesiredCount int `json:"DesiredCount"`
+}
+
+// ScheduleWindow is an individual active or upcoming window of a policy schedule.
+type ScheduleWindow struct {
+	Name         string
+	Start        int64
+	End          int64
+	Active       bool
+	MinCount     int
+	MaxCount     int
+	DesiredCount int
+}
+
+// Validate checks the schedule contains a parsable cron expression and time zone, along with
+// sensible overrides.
+func (s *PolicySchedule) Validate() error {
+	if _, err := cronexpr.Parse(s.Cron); err != nil {
+		return errors.Wrap(err, "failed to parse Cron")
+	}
+
+	if _, err := s.location(); err != nil {
+		return errors.Wrap(err, "failed to load TimeZone")
+	}
+
+	if s.Duration < 1 {
+		return errors.New("Duration must be greater than 0")
+	}
+
+	if s.MinCount < 0 || s.MaxCount < 0 || s.DesiredCount < 0 {
+		return errors.New("MinCount, MaxCount and DesiredCount must not be negative")
+	}
+
+	if s.MinCount == 0 && s.MaxCount == 0 && s.DesiredCount == 0 {
+		return errors.New("at least one of MinCount, MaxCount or DesiredCount must be set")
+	}
+
+	if s.MaxCount > 0 && s.MinCount > s.MaxCount {
+		return errors.New("MinCount must not be greater than MaxCount")
+	}
+	return nil
+}
+
+// location returns the time zone location of the schedule, defaulting to UTC.
+func (s *PolicySchedule) location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.TimeZone)
+}
+
+// windows returns the window of the schedule which is active at the passed time if any, and the
+// next window to start after the passed time.
+func (s *PolicySchedule) windows(name string, now time.Time) (active, next *ScheduleWindow, err error) {
+	expr, err := cronexpr.Parse(s.Cron)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loc, err := s.location()
+	if err != nil {
+		return nil, nil, err
+	}
+	now = now.In(loc)
+	duration := time.Duration(s.Duration) * time.Second
+
+	// A window is active if it started within the duration prior to now. The cron library finds
+	// the next time strictly after the passed time, therefore a window starting exactly at the
+	// lookback point has already ended.
+	if start := expr.Next(now.Add(-duration)); !start.IsZero() && !start.After(now) {
+		active = s.newWindow(name, start, duration, true)
+	}
+
+	if start := expr.Next(now); !start.IsZero() {
+		next = s.newWindow(name, start, duration, false)
+	}
+	return active, next, nil
+}
+
+func (s *PolicySchedule) newWindow(name string, start time.Time, duration time.Duration, active bool) *ScheduleWindow {
+	return &ScheduleWindow{
+		Name:         name,
+		Start:        start.UnixNano(),
+		End:          start.Add(duration).UnixNano(),
+		Active:       active,
+		MinCount:     s.MinCount,
+		MaxCount:     s.MaxCount,
+		DesiredCount: s.DesiredCount,
+	}
+}
+
+// GetScheduleWindows returns the currently active windows of the policy schedules, along with the
+// next upcoming window of each schedule. The windows are sorted by their start time.
+func (c *ClientScalingPolicy) GetScheduleWindows(now time.Time) ([]*ScheduleWindow, error) {
+	out := []*ScheduleWindow{}
+
+	for name, schedule := range c.Schedules {
+		active, next, err := schedule.windows(name, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to calculate windows of schedule: "+name)
+		}
+		if active != nil {
+			out = append(out, active)
+		}
+		if next != nil {
+			out = append(out, next)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Start != out[j].Start {
+			return out[i].Start < out[j].Start
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// GetActiveScheduleWindow returns the schedule window which is active at the passed time. If
+// multiple windows are active, the most recently started takes priority, with ties broken in
+// favour of the schedule name which sorts first. Nil is returned if no windows are active.
+func (c *ClientScalingPolicy) GetActiveScheduleWindow(now time.Time) (*ScheduleWindow, error) {
+	windows, err := c.GetScheduleWindows(now)
+	if err != nil {
+		return nil, err
+	}
+
+	var active *ScheduleWindow
+
+	for _, w := range windows {
+		if w.Active && (active == nil || w.Start > active.Start) {
+			active = w
+		}
+	}
+	return active, nil
+}
+
+// WithScheduleWindow returns a copy of the policy with the capacity overrides of the window
+// applied. The desired count of the window is bounded by the resulting minimum and maximum counts
+// and returned alongside, with 0 indicating no desired count.
+func (c *ClientScalingPolicy) WithScheduleWindow(w *ScheduleWindow) (*ClientScalingPolicy, int) {
+	pol := *c
+
+	if w == nil {
+		return &pol, 0
+	}
+
+	if w.MinCount > 0 {
+		pol.MinCount = w.MinCount
+	}
+	if w.MaxCount > 0 {
+		pol.MaxCount = w.MaxCount
+	}
+
+	desired := w.DesiredCount
+	if desired > 0 {
+		if desired < pol.MinCount {
+			desired = pol.MinCount
+		}
+		if desired > pol.MaxCount {
+			desired = pol.MaxCount
+		}
+	}
+	return &pol, desired
+}
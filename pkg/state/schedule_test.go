@@ -0,0 +1,158 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicySchedule_Validate(t *testing.T) {
+	testCases := []struct {
+		inputSchedule *PolicySchedule
+		expectError   bool
+		name          string
+	}{
+		{
+			inputSchedule: &PolicySchedule{Cron: "0 8 * * 1-5", TimeZone: "Europe/London", Duration: 3600, MinCount: 3},
+			expectError:   false,
+			name:          "valid schedule",
+		},
+		{
+			inputSchedule: &PolicySchedule{Cron: "not a cron", Duration: 3600, MinCount: 3},
+			expectError:   true,
+			name:          "invalid cron expression",
+		},
+		{
+			inputSchedule: &PolicySchedule{Cron: "0 8 * * *", TimeZone: "Mars/Olympus", Duration: 3600, MinCount: 3},
+			expectError:   true,
+			name:          "invalid time zone",
+		},
+		{
+			inputSchedule: &PolicySchedule{Cron: "0 8 * * *", MinCount: 3},
+			expectError:   true,
+			name:          "missing duration",
+		},
+		{
+			inputSchedule: &PolicySchedule{Cron: "0 8 * * *", Duration: 3600},
+			expectError:   true,
+			name:          "no overrides",
+		},
+		{
+			inputSchedule: &PolicySchedule{Cron: "0 8 * * *", Duration: 3600, MinCount: 5, MaxCount: 2},
+			expectError:   true,
+			name:          "min greater than max",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputSchedule.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestClientScalingPolicy_GetScheduleWindows(t *testing.T) {
+	pol := &ClientScalingPolicy{
+		Schedules: map[string]*PolicySchedule{
+			"business-hours": {Cron: "0 8 * * *", Duration: 36000, MinCount: 4},
+			"overnight":      {Cron: "0 22 * * *", Duration: 28800, MaxCount: 2},
+			"batch":          {Cron: "0 9 * * *", Duration: 3600, DesiredCount: 6},
+		},
+	}
+	now := time.Date(2019, 12, 17, 9, 30, 0, 0, time.UTC)
+
+	windows, err := pol.GetScheduleWindows(now)
+	assert.Nil(t, err)
+	assert.Equal(t, []*ScheduleWindow{
+		{
+			Name:     "business-hours",
+			Start:    time.Date(2019, 12, 17, 8, 0, 0, 0, time.UTC).UnixNano(),
+			End:      time.Date(2019, 12, 17, 18, 0, 0, 0, time.UTC).UnixNano(),
+			Active:   true,
+			MinCount: 4,
+		},
+		{
+			Name:         "batch",
+			Start:        time.Date(2019, 12, 17, 9, 0, 0, 0, time.UTC).UnixNano(),
+			End:          time.Date(2019, 12, 17, 10, 0, 0, 0, time.UTC).UnixNano(),
+			Active:       true,
+			DesiredCount: 6,
+		},
+		{
+			Name:     "overnight",
+			Start:    time.Date(2019, 12, 17, 22, 0, 0, 0, time.UTC).UnixNano(),
+			End:      time.Date(2019, 12, 18, 6, 0, 0, 0, time.UTC).UnixNano(),
+			MaxCount: 2,
+		},
+		{
+			Name:     "business-hours",
+			Start:    time.Date(2019, 12, 18, 8, 0, 0, 0, time.UTC).UnixNano(),
+			End:      time.Date(2019, 12, 18, 18, 0, 0, 0, time.UTC).UnixNano(),
+			MinCount: 4,
+		},
+		{
+			Name:         "batch",
+			Start:        time.Date(2019, 12, 18, 9, 0, 0, 0, time.UTC).UnixNano(),
+			End:          time.Date(2019, 12, 18, 10, 0, 0, 0, time.UTC).UnixNano(),
+			DesiredCount: 6,
+		},
+	}, windows)
+
+	active, err := pol.GetActiveScheduleWindow(now)
+	assert.Nil(t, err)
+	assert.Equal(t, "batch", active.Name, "most recently started window takes priority")
+
+	active, err = pol.GetActiveScheduleWindow(time.Date(2019, 12, 17, 5, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, "overnight", active.Name, "window spanning midnight")
+
+	active, err = pol.GetActiveScheduleWindow(time.Date(2019, 12, 17, 20, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Nil(t, active, "no active window")
+}
+
+func TestClientScalingPolicy_WithScheduleWindow(t *testing.T) {
+	pol := &ClientScalingPolicy{MinCount: 2, MaxCount: 10}
+
+	testCases := []struct {
+		inputWindow     *ScheduleWindow
+		expectedMin     int
+		expectedMax     int
+		expectedDesired int
+		name            string
+	}{
+		{
+			inputWindow:     nil,
+			expectedMin:     2,
+			expectedMax:     10,
+			expectedDesired: 0,
+			name:            "no active window",
+		},
+		{
+			inputWindow:     &ScheduleWindow{MinCount: 4},
+			expectedMin:     4,
+			expectedMax:     10,
+			expectedDesired: 0,
+			name:            "min count override",
+		},
+		{
+			inputWindow:     &ScheduleWindow{MaxCount: 3, DesiredCount: 5},
+			expectedMin:     2,
+			expectedMax:     3,
+			expectedDesired: 3,
+			name:            "desired count bounded by max count",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualPolicy, actualDesired := pol.WithScheduleWindow(tc.inputWindow)
+		assert.Equal(t, tc.expectedMin, actualPolicy.MinCount, tc.name)
+		assert.Equal(t, tc.expectedMax, actualPolicy.MaxCount, tc.name)
+		assert.Equal(t, tc.expectedDesired, actualDesired, tc.name)
+	}
+	assert.Equal(t, 2, pol.MinCount, "original policy not modified")
+}
@@ -0,0 +1,150 @@
+package state
+
+import (
+	"github.com/pkg/errors"
+)
+
+// NodeSelectorStrategy identifies how the node removed during a scale in activity is selected
+// from the nodes of the class.
+type NodeSelectorStrategy string
+
+// String returns the string form of the NodeSelectorStrategy.
+func (ns NodeSelectorStrategy) String() string { return string(ns) }
+
+// Validate checks the NodeSelectorStrategy is valid and that it can be handled within Chemtrail.
+func (ns NodeSelectorStrategy) Validate() error {
+	switch ns {
+	case NodeSelectorLeastAllocated, NodeSelectorOldest, NodeSelectorNewest,
+		NodeSelectorFewestAllocs, NodeSelectorAZBalanced, NodeSelectorRandom:
+		return nil
+	default:
+		return errors.Errorf("ScaleInSelector Strategy \"%s\" is not a valid option", ns.String())
+	}
+}
+
+const (
+	// NodeSelectorLeastAllocated selects the node with the lowest allocated resource percentage,
+	// reducing the amount of work which needs to be migrated across the cluster.
+	NodeSelectorLeastAllocated NodeSelectorStrategy = "least-allocated"
+
+	// NodeSelectorOldest selects the node which registered with the Nomad cluster first.
+	NodeSelectorOldest NodeSelectorStrategy = "oldest"
+
+	// NodeSelectorNewest selects the node which registered with the Nomad cluster most recently.
+	NodeSelectorNewest NodeSelectorStrategy = "newest"
+
+	// NodeSelectorFewestAllocs selects the node running the fewest allocations.
+	NodeSelectorFewestAllocs NodeSelectorStrategy = "fewest-allocs"
+
+	// NodeSelectorAZBalanced selects the least allocated node from the availability zone which
+	// contains the most nodes of the class, keeping the class balanced across zones.
+	NodeSelectorAZBalanced NodeSelectorStrategy = "az-balanced"
+
+	// NodeSelectorRandom selects a node at random.
+	NodeSelectorRandom NodeSelectorStrategy = "random"
+)
+
+// DefaultAZAttribute is the Nomad node attribute used to identify the availability zone of a node
+// when the az-balanced selector does not specify one.
+const DefaultAZAttribute = "platform.aws.placement.availability-zone"
+
+// ScaleInSelector configures how the node removed during a scale in activity is selected.
+type ScaleInSelector struct {
+
+	// Strategy is the node selection strategy. Defaults to least-allocated.
+	Strategy NodeSelectorStrategy `json:"Strategy"`
+
+	// Resource is the resource compared by the least-allocated and az-balanced strategies. When
+	// neither Resource nor Weights are set, cpu and memory are weighted equally.
+	Resource ScaleResource `json:"Resource"`
+
+	// Weights is a map of resources to the weight given to their allocated percentage, allowing
+	// the least-allocated and az-balanced strategies to compare a combined score. Cannot be used
+	// alongside Resource.
+	Weights map[ScaleResource]float64 `json:"Weights"`
+
+	// Attribute is the Nomad node attribute identifying the availability zone of the node, used
+	// by the az-balanced strategy. Defaults to platform.aws.placement.availability-zone.
+	Attribute string `json:"Attribute"`
+}
+
+// GetStrategy returns the node selection strategy, applying the default if the selector or
+// strategy has not been set.
+func (s *ScaleInSelector) GetStrategy() NodeSelectorStrategy {
+	if s == nil || s.Strategy == "" {
+		return NodeSelectorLeastAllocated
+	}
+	return s.Strategy
+}
+
+// GetWeights returns the resource weights used to score the allocation of a node. A configured
+// Resource is given the full weight, and when neither Resource nor Weights are set, cpu and
+// memory are weighted equally.
+func (s *ScaleInSelector) GetWeights() map[ScaleResource]float64 {
+	switch {
+	case s != nil && s.Resource != "":
+		return map[ScaleResource]float64{s.Resource: 1}
+	case s != nil && len(s.Weights) > 0:
+		return s.Weights
+	default:
+		return map[ScaleResource]float64{ScaleResourceCPU: 1, ScaleResourceMemory: 1}
+	}
+}
+
+// GetAttribute returns the availability zone node attribute, applying the default if the
+// selector or attribute has not been set.
+func (s *ScaleInSelector) GetAttribute() string {
+	if s == nil || s.Attribute == "" {
+		return DefaultAZAttribute
+	}
+	return s.Attribute
+}
+
+// Validate checks the ScaleInSelector contains a supported strategy and resource configuration.
+func (s *ScaleInSelector) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.GetStrategy().Validate(); err != nil {
+		return err
+	}
+
+	if s.Resource != "" && len(s.Weights) > 0 {
+		return errors.New("ScaleInSelector Resource and Weights cannot both be set")
+	}
+
+	if s.Resource != "" {
+		if err := validateSelectorResource(s.Resource); err != nil {
+			return err
+		}
+	}
+
+	var total float64
+	for resource, weight := range s.Weights {
+		if err := validateSelectorResource(resource); err != nil {
+			return err
+		}
+		if weight < 0 {
+			return errors.Errorf("ScaleInSelector weight of \"%s\" must not be negative", resource.String())
+		}
+		total += weight
+	}
+
+	if len(s.Weights) > 0 && total == 0 {
+		return errors.New("ScaleInSelector Weights must contain at least one positive weight")
+	}
+	return nil
+}
+
+// validateSelectorResource checks the resource is a per-node allocated percentage which can be
+// compared by the node selector.
+func validateSelectorResource(r ScaleResource) error {
+	switch r {
+	case ScaleResourceCPU, ScaleResourceMemory, ScaleResourceDisk, ScaleResourceNetwork,
+		ScaleResourceDevices, ScaleResourceCPUUsed, ScaleResourceMemoryUsed:
+		return nil
+	default:
+		return errors.Errorf("ScaleInSelector Resource \"%s\" is not a valid option", r.String())
+	}
+}
@@ -0,0 +1,104 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleInSelector_Validate(t *testing.T) {
+	testCases := []struct {
+		inputSelector *ScaleInSelector
+		expectError   bool
+		name          string
+	}{
+		{
+			inputSelector: nil,
+			expectError:   false,
+			name:          "nil selector",
+		},
+		{
+			inputSelector: &ScaleInSelector{Strategy: NodeSelectorOldest},
+			expectError:   false,
+			name:          "valid oldest selector",
+		},
+		{
+			inputSelector: &ScaleInSelector{Resource: ScaleResourceMemory},
+			expectError:   false,
+			name:          "valid default strategy with resource",
+		},
+		{
+			inputSelector: &ScaleInSelector{
+				Strategy: NodeSelectorLeastAllocated,
+				Weights:  map[ScaleResource]float64{ScaleResourceCPU: 2, ScaleResourceMemory: 1},
+			},
+			expectError: false,
+			name:        "valid weights",
+		},
+		{
+			inputSelector: &ScaleInSelector{Strategy: "largest"},
+			expectError:   true,
+			name:          "unsupported strategy",
+		},
+		{
+			inputSelector: &ScaleInSelector{Resource: ScaleResourceQueuedAllocs},
+			expectError:   true,
+			name:          "unsupported resource",
+		},
+		{
+			inputSelector: &ScaleInSelector{
+				Resource: ScaleResourceCPU,
+				Weights:  map[ScaleResource]float64{ScaleResourceMemory: 1},
+			},
+			expectError: true,
+			name:        "resource and weights",
+		},
+		{
+			inputSelector: &ScaleInSelector{Weights: map[ScaleResource]float64{ScaleResourceCPU: -1}},
+			expectError:   true,
+			name:          "negative weight",
+		},
+		{
+			inputSelector: &ScaleInSelector{Weights: map[ScaleResource]float64{ScaleResourceCPU: 0}},
+			expectError:   true,
+			name:          "zero total weight",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputSelector.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestScaleInSelector_GetWeights(t *testing.T) {
+	testCases := []struct {
+		inputSelector  *ScaleInSelector
+		expectedOutput map[ScaleResource]float64
+		name           string
+	}{
+		{
+			inputSelector:  nil,
+			expectedOutput: map[ScaleResource]float64{ScaleResourceCPU: 1, ScaleResourceMemory: 1},
+			name:           "nil selector",
+		},
+		{
+			inputSelector:  &ScaleInSelector{Resource: ScaleResourceDisk},
+			expectedOutput: map[ScaleResource]float64{ScaleResourceDisk: 1},
+			name:           "resource",
+		},
+		{
+			inputSelector:  &ScaleInSelector{Weights: map[ScaleResource]float64{ScaleResourceCPU: 3}},
+			expectedOutput: map[ScaleResource]float64{ScaleResourceCPU: 3},
+			name:           "weights",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, tc.inputSelector.GetWeights(), tc.name)
+	}
+}
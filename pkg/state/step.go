@@ -0,0 +1,107 @@
+package state
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// StepAdjustment defines the number of nodes a check should scale by when the checked resource
+// value falls within the bounds of the step. This allows the size of a scaling activity to be
+// proportional to how far the threshold has been breached.
+type StepAdjustment struct {
+
+	// LowerBound is the inclusive lower bound of the resource value for which the step applies.
+	LowerBound float64 `json:"LowerBound"`
+
+	// UpperBound is the exclusive upper bound of the resource value for which the step applies. A
+	// value of 0 indicates the step has no upper bound.
+	UpperBound float64 `json:"UpperBound"`
+
+	// Adjustment is the number of nodes, or percentage of the current class size, to scale by.
+	Adjustment int `json:"Adjustment"`
+
+	// AdjustmentType identifies how the Adjustment value is used. Defaults to count.
+	AdjustmentType AdjustmentType `json:"AdjustmentType"`
+}
+
+// Validate checks the StepAdjustment has a valid range and adjustment.
+func (sa StepAdjustment) Validate() error {
+	if sa.Adjustment < 1 {
+		return errors.New("Adjustment must be greater than 0")
+	}
+
+	if sa.UpperBound != 0 && sa.UpperBound <= sa.LowerBound {
+		return errors.New("UpperBound must be greater than LowerBound")
+	}
+	return sa.GetAdjustmentType().Validate()
+}
+
+// GetAdjustmentType returns the adjustment type of the step, applying the default if the value
+// has not been set.
+func (sa StepAdjustment) GetAdjustmentType() AdjustmentType {
+	if sa.AdjustmentType == "" {
+		return AdjustmentTypeCount
+	}
+	return sa.AdjustmentType
+}
+
+// Contains identifies whether the resource value falls within the bounds of the step.
+func (sa StepAdjustment) Contains(value float64) bool {
+	if value < sa.LowerBound {
+		return false
+	}
+	return sa.UpperBound == 0 || value < sa.UpperBound
+}
+
+// Count returns the number of nodes the step scales by, based on the current number of nodes
+// within the class. Percentage adjustments are rounded up, and always scale by at least 1 node.
+func (sa StepAdjustment) Count(current int) int {
+	switch sa.GetAdjustmentType() {
+	case AdjustmentTypePercent:
+		count := int(math.Ceil(float64(current*sa.Adjustment) / 100))
+		if count < 1 {
+			return 1
+		}
+		return count
+	default:
+		return sa.Adjustment
+	}
+}
+
+// GetStepCount returns the number of nodes the check should scale by for the resource value,
+// using the first step adjustment which contains the value. If the check has no step adjustments
+// or none contain the value, 0 is returned indicating the policy count should be used.
+func (pc PolicyCheck) GetStepCount(value float64, current int) int {
+	for _, step := range pc.StepAdjustments {
+		if step.Contains(value) {
+			return step.Count(current)
+		}
+	}
+	return 0
+}
+
+// AdjustmentType identifies how a StepAdjustment value is used to calculate the number of nodes
+// to scale by.
+type AdjustmentType string
+
+// String returns the string form of the AdjustmentType.
+func (at AdjustmentType) String() string { return string(at) }
+
+// Validate checks the AdjustmentType is valid and that it can be handled within Chemtrail.
+func (at AdjustmentType) Validate() error {
+	switch at {
+	case AdjustmentTypeCount, AdjustmentTypePercent:
+		return nil
+	default:
+		return errors.Errorf("AdjustmentType \"%s\" is not a valid option", at.String())
+	}
+}
+
+const (
+	// AdjustmentTypeCount scales by the Adjustment number of nodes.
+	AdjustmentTypeCount AdjustmentType = "count"
+
+	// AdjustmentTypePercent scales by the Adjustment percentage of the current class size.
+	AdjustmentTypePercent AdjustmentType = "percent"
+)
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepAdjustment_Validate(t *testing.T) {
+	testCases := []struct {
+		inputStep   StepAdjustment
+		expectError bool
+		name        string
+	}{
+		{
+			inputStep:   StepAdjustment{LowerBound: 80, UpperBound: 90, Adjustment: 1},
+			expectError: false,
+			name:        "valid bounded count step",
+		},
+		{
+			inputStep:   StepAdjustment{LowerBound: 90, Adjustment: 20, AdjustmentType: AdjustmentTypePercent},
+			expectError: false,
+			name:        "valid unbounded percent step",
+		},
+		{
+			inputStep:   StepAdjustment{LowerBound: 90, UpperBound: 80, Adjustment: 1},
+			expectError: true,
+			name:        "upper bound below lower bound",
+		},
+		{
+			inputStep:   StepAdjustment{LowerBound: 80},
+			expectError: true,
+			name:        "missing adjustment",
+		},
+		{
+			inputStep:   StepAdjustment{LowerBound: 80, Adjustment: 1, AdjustmentType: "nodes"},
+			expectError: true,
+			name:        "invalid adjustment type",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputStep.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestPolicyCheck_GetStepCount(t *testing.T) {
+	check := PolicyCheck{
+		StepAdjustments: []*StepAdjustment{
+			{LowerBound: 80, UpperBound: 90, Adjustment: 1},
+			{LowerBound: 90, UpperBound: 95, Adjustment: 3},
+			{LowerBound: 95, Adjustment: 50, AdjustmentType: AdjustmentTypePercent},
+		},
+	}
+
+	testCases := []struct {
+		inputValue     float64
+		inputCurrent   int
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputValue:     70,
+			inputCurrent:   5,
+			expectedOutput: 0,
+			name:           "no step contains value",
+		},
+		{
+			inputValue:     80,
+			inputCurrent:   5,
+			expectedOutput: 1,
+			name:           "inclusive lower bound",
+		},
+		{
+			inputValue:     90,
+			inputCurrent:   5,
+			expectedOutput: 3,
+			name:           "exclusive upper bound",
+		},
+		{
+			inputValue:     99,
+			inputCurrent:   5,
+			expectedOutput: 3,
+			name:           "percentage of class rounded up",
+		},
+		{
+			inputValue:     99,
+			inputCurrent:   0,
+			expectedOutput: 1,
+			name:           "percentage of empty class",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := check.GetStepCount(tc.inputValue, tc.inputCurrent)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
@@ -0,0 +1,87 @@
+package state
+
+import (
+	"github.com/pkg/errors"
+)
+
+// PolicyStrategy identifies the decision strategy the autoscaler uses when evaluating a scaling
+// policy.
+type PolicyStrategy string
+
+// String returns the string form of the PolicyStrategy.
+func (ps PolicyStrategy) String() string { return string(ps) }
+
+// Validate checks the PolicyStrategy is valid and that it can be handled within the autoscaler.
+func (ps PolicyStrategy) Validate() error {
+	switch ps {
+	case StrategyThreshold, StrategyTargetTracking:
+		return nil
+	default:
+		return errors.Errorf("Strategy \"%s\" is not a valid option", ps.String())
+	}
+}
+
+const (
+	// StrategyThreshold evaluates the policy Checks, scaling when their thresholds are breached.
+	StrategyThreshold PolicyStrategy = "threshold"
+
+	// StrategyTargetTracking evaluates the policy Targets, calculating the number of nodes
+	// required to keep the resource utilisation at the target value.
+	StrategyTargetTracking PolicyStrategy = "target-tracking"
+)
+
+const (
+	// defaultTargetTolerance is the default tolerance band in percentage points around a tracking
+	// target within which no scaling takes place.
+	defaultTargetTolerance = 5
+)
+
+// PolicyTarget is an individual resource utilisation target used by the target tracking strategy.
+type PolicyTarget struct {
+
+	// Enabled is a boolean flag to identify whether this specific target should be actively
+	// tracked or not.
+	Enabled bool `json:"Enabled"`
+
+	// Resource identifies the Nomad resource which is tracked. Only the percentage based cpu and
+	// memory resources are supported.
+	Resource ScaleResource `json:"Resource"`
+
+	// TargetValue is the allocated resource percentage the autoscaler attempts to maintain.
+	TargetValue float64 `json:"TargetValue"`
+
+	// Tolerance is the band in percentage points either side of the TargetValue within which no
+	// scaling takes place. This avoids the class size oscillating. Defaults to 5.
+	Tolerance float64 `json:"Tolerance"`
+
+	// MaxChange is the maximum number of nodes which can be added or removed during a single
+	// evaluation. A value of 0 does not limit the change.
+	MaxChange int `json:"MaxChange"`
+}
+
+// GetTolerance returns the tolerance of the target, applying the default if the value has not been
+// set.
+func (pt PolicyTarget) GetTolerance() float64 {
+	if pt.Tolerance == 0 {
+		return defaultTargetTolerance
+	}
+	return pt.Tolerance
+}
+
+// Validate checks the PolicyTarget contains a supported resource and sensible values.
+func (pt PolicyTarget) Validate() error {
+	switch pt.Resource {
+	case ScaleResourceCPU, ScaleResourceMemory, ScaleResourceCPUUsed, ScaleResourceMemoryUsed:
+	default:
+		return errors.Errorf("Resource \"%s\" is not a valid target tracking option", pt.Resource.String())
+	}
+
+	if pt.TargetValue <= 0 || pt.TargetValue > 100 {
+		return errors.New("TargetValue must be greater than 0 and less than or equal to 100")
+	}
+
+	if pt.Tolerance < 0 || pt.MaxChange < 0 {
+		return errors.New("Tolerance and MaxChange must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyTarget_Validate(t *testing.T) {
+	testCases := []struct {
+		inputTarget PolicyTarget
+		expectError bool
+		name        string
+	}{
+		{
+			inputTarget: PolicyTarget{Resource: ScaleResourceCPU, TargetValue: 65},
+			expectError: false,
+			name:        "valid cpu target",
+		},
+		{
+			inputTarget: PolicyTarget{Resource: ScaleResourceMemoryUsed, TargetValue: 65},
+			expectError: false,
+			name:        "valid memory used target",
+		},
+		{
+			inputTarget: PolicyTarget{Resource: ScaleResourceQueuedAllocs, TargetValue: 65},
+			expectError: true,
+			name:        "unsupported resource",
+		},
+		{
+			inputTarget: PolicyTarget{Resource: ScaleResourceMemory, TargetValue: 120},
+			expectError: true,
+			name:        "target value above 100",
+		},
+		{
+			inputTarget: PolicyTarget{Resource: ScaleResourceMemory, TargetValue: 65, MaxChange: -1},
+			expectError: true,
+			name:        "negative max change",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputTarget.Validate()
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
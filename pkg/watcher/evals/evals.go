@@ -0,0 +1,73 @@
+package evals
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/chemtrail/pkg/watcher"
+	"github.com/rs/zerolog"
+)
+
+type Watcher struct {
+	logger          zerolog.Logger
+	nomad           *api.Client
+	lastChangeIndex uint64
+}
+
+func NewWatcher(logger zerolog.Logger, nomad *api.Client) watcher.Watcher {
+	return &Watcher{
+		logger: logger,
+		nomad:  nomad,
+	}
+}
+
+func (w *Watcher) Run(updateChan chan interface{}) {
+	w.logger.Info().Msg("starting Chemtrail Nomad evaluation watcher")
+
+	var maxFound uint64
+
+	q := &api.QueryOptions{WaitTime: 5 * time.Minute, WaitIndex: 1}
+
+	for {
+
+		evals, meta, err := w.nomad.Evaluations().List(q)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("failed to call Nomad API for evaluation listing")
+			time.Sleep(10 * time.Second)
+			continue
+		}
+
+		if !watcher.IndexHasChange(meta.LastIndex, q.WaitIndex) {
+			w.logger.Debug().Msg("evaluation watcher last index has not changed")
+			continue
+		}
+		w.logger.Debug().
+			Uint64("old", q.WaitIndex).
+			Uint64("new", meta.LastIndex).
+			Msg("evaluation watcher last index has changed")
+
+		// Iterate over all the returned evaluations. The list endpoint returns the full
+		// evaluation object, therefore no further info calls are required.
+		for i := range evals {
+
+			if !watcher.IndexHasChange(evals[i].ModifyIndex, w.lastChangeIndex) {
+				continue
+			}
+
+			w.logger.Debug().
+				Uint64("old", w.lastChangeIndex).
+				Uint64("new", evals[i].ModifyIndex).
+				Str("eval-id", evals[i].ID).
+				Msg("evaluation modify index has changed is greater than last recorded")
+
+			maxFound = watcher.MaxFound(evals[i].ModifyIndex, maxFound)
+			updateChan <- evals[i]
+		}
+
+		// Update the Nomad API wait index to start long polling from the correct point and update
+		// our recorded lastChangeIndex so we have the correct point to use during the next API
+		// return.
+		q.WaitIndex = meta.LastIndex
+		w.lastChangeIndex = maxFound
+	}
+}